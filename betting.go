@@ -0,0 +1,92 @@
+package poker
+
+// BettingState is everything a BettingStructure needs to know to size a bet
+// or raise for the player whose turn it is. It doesn't name the street, since
+// every game has its own; the game says which streets are bet in big bets.
+type BettingState struct {
+	// BigBlind is the smallest full bet: the big blind, or the small bet in
+	// stud
+	BigBlind int
+
+	// BigBets is true on the streets a fixed-limit game bets and raises in big
+	// bets: the turn and river in Hold 'em, or fifth street on in stud
+	BigBets bool
+
+	// CurrentBet is the bet everybody has to match on this street, and
+	// LastRaise is the size of the last full bet or raise (the big blind if
+	// there hasn't been one)
+	CurrentBet int
+	LastRaise  int
+
+	// Raises counts the full bets and raises made on this street. Preflop, the
+	// big blind counts as the first bet.
+	Raises int
+
+	// Pot is every chip put in during the hand, including this street's bets
+	Pot int
+
+	// PlayerBet is what the player has already put in on this street, and
+	// Stack is what they have left
+	PlayerBet int
+	Stack     int
+}
+
+// toCall returns how many chips the player needs to call
+func (s BettingState) toCall() int {
+	return s.CurrentBet - s.PlayerBet
+}
+
+// BettingStructure decides how big a bet or raise can be. RaiseLimits returns
+// the smallest and largest totals the player may bet or raise to for the
+// street, or a zero max if they can't bet or raise at all. Limits don't need
+// to account for the player's stack: a Table always allows going all in for
+// less than the minimum, and never takes more than a player has.
+type BettingStructure interface {
+	RaiseLimits(s BettingState) (min, max int)
+}
+
+// NoLimit lets a player bet anything from a full raise up to their whole stack
+type NoLimit struct{}
+
+// RaiseLimits implements BettingStructure
+func (NoLimit) RaiseLimits(s BettingState) (min, max int) {
+	return s.CurrentBet + s.LastRaise, s.PlayerBet + s.Stack
+}
+
+// PotLimit caps each bet or raise at the size of the pot. A raise is sized as
+// if the player called first, so the most they can raise to is the current
+// bet, plus the pot, plus what they'd need to call.
+type PotLimit struct{}
+
+// RaiseLimits implements BettingStructure
+func (PotLimit) RaiseLimits(s BettingState) (min, max int) {
+	min = s.CurrentBet + s.LastRaise
+	max = s.CurrentBet + s.Pot + s.toCall()
+	if max < min {
+		max = min
+	}
+	return min, max
+}
+
+// FixedLimit allows bets and raises of exactly SmallBet on the early streets
+// and exactly BigBet on the streets the game plays for big bets. MaxRaises caps the
+// number of bets and raises on each street, counting the opening bet (or the
+// big blind); a cap of four is common. Zero means there's no cap.
+type FixedLimit struct {
+	SmallBet  int
+	BigBet    int
+	MaxRaises int
+}
+
+// RaiseLimits implements BettingStructure
+func (fl FixedLimit) RaiseLimits(s BettingState) (min, max int) {
+	if fl.MaxRaises > 0 && s.Raises >= fl.MaxRaises {
+		return 0, 0
+	}
+
+	var size = fl.SmallBet
+	if s.BigBets {
+		size = fl.BigBet
+	}
+	return s.CurrentBet + size, s.CurrentBet + size
+}
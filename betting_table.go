@@ -0,0 +1,378 @@
+package poker
+
+import (
+	"fmt"
+)
+
+// tableGame is what a bettingTable needs from the game being dealt
+type tableGame interface {
+	// endStreet is called once the betting on a street is over, to deal the
+	// next one or go to showdown
+	endStreet()
+
+	// finish is called when the hand is over, either after showdown or when
+	// everybody but one player folds
+	finish()
+}
+
+// bettingTable is everything about a table which doesn't depend on the game
+// being dealt: the seats and their chips, whose turn it is, what they may do,
+// and settling the pots. Games embed it, so every game bets by the same rules.
+type bettingTable struct {
+	game      tableGame
+	seats     []*Seat
+	structure BettingStructure
+
+	// minBet is the smallest full bet, and the size of a raise until somebody
+	// makes a bigger one: the big blind in flop games, or the small bet in stud
+	minBet int
+
+	inProgress bool
+	toAct      int
+	currentBet int
+	lastRaise  int
+	raises     int
+
+	// bigBets is true on the streets a fixed-limit game bets in big bets
+	bigBets bool
+
+	// incomplete is true while the only bet is a forced one for less than a
+	// full bet, like stud's bring-in, so the first raise only has to complete
+	// it to a full bet
+	incomplete bool
+
+	results []*HandResult
+	won     []int
+}
+
+// SetBettingStructure changes how much players may bet and raise, starting
+// with the next hand
+func (t *bettingTable) SetBettingStructure(bs BettingStructure) error {
+	if t.inProgress {
+		return ErrHandInProgress
+	}
+	t.structure = bs
+	return nil
+}
+
+// Sit puts a player with the given chips into an empty seat. Players may sit
+// down during a hand, but aren't dealt in until the next one.
+func (t *bettingTable) Sit(seat int, name string, stack int) error {
+	if seat < 0 || seat >= len(t.seats) {
+		return fmt.Errorf("%w: %d", ErrInvalidSeat, seat)
+	}
+	if t.seats[seat] != nil {
+		return fmt.Errorf("%w: %d", ErrSeatTaken, seat)
+	}
+	if stack <= 0 {
+		return fmt.Errorf("%w: a player needs chips to sit down", ErrInvalidAmount)
+	}
+
+	t.seats[seat] = &Seat{Name: name, Stack: stack}
+	return nil
+}
+
+// Stand removes a player from the table. A player who was dealt into the
+// current hand can't leave until it's over.
+func (t *bettingTable) Stand(seat int) error {
+	if seat < 0 || seat >= len(t.seats) || t.seats[seat] == nil {
+		return fmt.Errorf("%w: %d", ErrInvalidSeat, seat)
+	}
+	if t.inProgress && t.seats[seat].Hand != nil {
+		return ErrHandInProgress
+	}
+
+	t.seats[seat] = nil
+	return nil
+}
+
+// NumSeats returns how many seats the table has, taken or not
+func (t *bettingTable) NumSeats() int {
+	return len(t.seats)
+}
+
+// Seat returns a copy of the player in the given seat, or false if the seat
+// is empty or doesn't exist
+func (t *bettingTable) Seat(seat int) (Seat, bool) {
+	if seat < 0 || seat >= len(t.seats) || t.seats[seat] == nil {
+		return Seat{}, false
+	}
+	return *t.seats[seat], true
+}
+
+// InProgress returns true if a hand has started and isn't finished
+func (t *bettingTable) InProgress() bool {
+	return t.inProgress
+}
+
+// ToAct returns the seat whose turn it is, or -1 if nobody can act
+func (t *bettingTable) ToAct() int {
+	return t.toAct
+}
+
+// CurrentBet returns the bet everybody has to match to stay in on this street
+func (t *bettingTable) CurrentBet() int {
+	return t.currentBet
+}
+
+// Pot returns every chip put in during the current (or most recent) hand,
+// including bets on the current street
+func (t *bettingTable) Pot() int {
+	var pot int
+	for _, s := range t.seats {
+		if s != nil {
+			pot += s.Total
+		}
+	}
+	return pot
+}
+
+// Results returns each seat's showdown hand from the most recent hand, or nil
+// for seats which didn't get to showdown. The list is nil if the hand didn't
+// go to showdown at all.
+func (t *bettingTable) Results() []*HandResult {
+	return t.results
+}
+
+// Won returns how many chips each seat collected from the pot in the most
+// recent hand
+func (t *bettingTable) Won() []int {
+	return t.won
+}
+
+// nextSeat returns the first seat after from which satisfies fn, or -1
+func (t *bettingTable) nextSeat(from int, fn func(s *Seat) bool) int {
+	for i := 1; i <= len(t.seats); i++ {
+		var n = (from + i) % len(t.seats)
+		if t.seats[n] != nil && fn(t.seats[n]) {
+			return n
+		}
+	}
+	return -1
+}
+
+// Legal returns what the player to act may do. The zero value is returned if
+// nobody can act.
+func (t *bettingTable) Legal() LegalActions {
+	if !t.inProgress || t.toAct < 0 {
+		return LegalActions{}
+	}
+
+	var s = t.seats[t.toAct]
+	var legal LegalActions
+	var toCall = t.currentBet - s.Bet
+	if toCall <= 0 {
+		legal.Check = true
+	} else {
+		legal.Call = toCall
+		if legal.Call > s.Stack {
+			legal.Call = s.Stack
+		}
+	}
+
+	// Raising needs chips beyond a call, somebody left to call it, and a full
+	// bet or raise since this player last acted
+	var others = t.nextSeat(t.toAct, func(o *Seat) bool { return o != s && o.canAct() })
+	if s.Stack > toCall && others >= 0 && !s.acted {
+		var min, max = t.structure.RaiseLimits(t.bettingState(s))
+		if max > 0 {
+			var allIn = s.Bet + s.Stack
+			if max > allIn {
+				max = allIn
+			}
+			if min > max {
+				min = max
+			}
+			legal.MinRaise, legal.MaxRaise = min, max
+		}
+	}
+
+	return legal
+}
+
+// bettingState returns what the betting structure needs to size a raise for
+// the given player. An incomplete bet isn't counted, so the structure sizes
+// the first raise like an opening bet.
+func (t *bettingTable) bettingState(s *Seat) BettingState {
+	var current = t.currentBet
+	if t.incomplete {
+		current = 0
+	}
+
+	return BettingState{
+		BigBlind:   t.minBet,
+		BigBets:    t.bigBets,
+		CurrentBet: current,
+		LastRaise:  t.lastRaise,
+		Raises:     t.raises,
+		Pot:        t.Pot(),
+		PlayerBet:  s.Bet,
+		Stack:      s.Stack,
+	}
+}
+
+// act performs the action for the player whose turn it is. It's up to the
+// game to move on to the next player afterward.
+func (t *bettingTable) act(a Action) error {
+	if !t.inProgress || t.toAct < 0 {
+		return ErrNoHandInProgress
+	}
+
+	var s = t.seats[t.toAct]
+	var legal = t.Legal()
+	switch a.Type {
+	case Fold:
+		s.Folded = true
+
+	case Check:
+		if !legal.Check {
+			return fmt.Errorf("%w: can't check facing a bet of %d", ErrIllegalAction, t.currentBet)
+		}
+
+	case Call:
+		if legal.Call == 0 {
+			return fmt.Errorf("%w: there's nothing to call", ErrIllegalAction)
+		}
+		s.put(legal.Call)
+
+	case Bet, Raise:
+		if a.Type == Bet && t.currentBet != 0 {
+			return fmt.Errorf("%w: can't bet when there's already a bet; raise instead", ErrIllegalAction)
+		}
+		if a.Type == Raise && t.currentBet == 0 {
+			return fmt.Errorf("%w: can't raise when there's no bet; bet instead", ErrIllegalAction)
+		}
+		if legal.MaxRaise == 0 {
+			return fmt.Errorf("%w: raising isn't allowed", ErrIllegalAction)
+		}
+		if a.Amount < legal.MinRaise || a.Amount > legal.MaxRaise {
+			return fmt.Errorf("%w: %s must be from %d to %d", ErrIllegalAction, a.Type, legal.MinRaise, legal.MaxRaise)
+		}
+
+		// Anything short of the structure's minimum is an all-in for less
+		var state = t.bettingState(s)
+		var fullRaise, _ = t.structure.RaiseLimits(state)
+		s.put(a.Amount - s.Bet)
+		if a.Amount >= fullRaise {
+			// A full raise reopens the betting for everybody else
+			t.lastRaise = a.Amount - state.CurrentBet
+			t.raises++
+			t.incomplete = false
+			for _, o := range t.seats {
+				if o != nil {
+					o.acted = false
+				}
+			}
+		}
+		t.currentBet = a.Amount
+
+	default:
+		return fmt.Errorf("%w: unknown action %d", ErrIllegalAction, a.Type)
+	}
+
+	s.acted = true
+	return nil
+}
+
+// needsToAct returns true if the player still has a decision to make on this
+// street. A player who hasn't acted yet doesn't need to if nobody else could
+// respond to a bet.
+func (t *bettingTable) needsToAct(s *Seat) bool {
+	if !s.canAct() {
+		return false
+	}
+	if s.Bet < t.currentBet {
+		return true
+	}
+	if s.acted {
+		return false
+	}
+	var others = t.nextSeat(-1, func(o *Seat) bool { return o != s && o.canAct() })
+	return others >= 0
+}
+
+// advance finds the next player to act, or ends the street (or the hand) if
+// the betting is over
+func (t *bettingTable) advance() {
+	var inHand = t.nextSeat(-1, (*Seat).inHand)
+	if t.nextSeat(inHand, (*Seat).inHand) == inHand {
+		t.game.finish()
+		return
+	}
+
+	t.toAct = t.nextSeat(t.toAct, t.needsToAct)
+	if t.toAct < 0 {
+		t.game.endStreet()
+	}
+}
+
+// newStreet returns any uncalled bet and clears everybody's bets for the next
+// street
+func (t *bettingTable) newStreet() {
+	t.returnUncalled()
+	for _, s := range t.seats {
+		if s != nil {
+			s.Bet = 0
+			s.acted = false
+		}
+	}
+	t.currentBet = 0
+	t.lastRaise = t.minBet
+	t.raises = 0
+	t.incomplete = false
+}
+
+// returnUncalled gives back whatever part of the biggest bet nobody else
+// matched
+func (t *bettingTable) returnUncalled() {
+	var top, second = -1, 0
+	for i, s := range t.seats {
+		if s == nil || s.Hand == nil {
+			continue
+		}
+		switch {
+		case top < 0 || s.Bet > t.seats[top].Bet:
+			if top >= 0 {
+				second = t.seats[top].Bet
+			}
+			top = i
+		case s.Bet > second:
+			second = s.Bet
+		}
+	}
+
+	var s = t.seats[top]
+	if s.Bet > second {
+		var extra = s.Bet - second
+		s.Bet -= extra
+		s.Total -= extra
+		s.Stack += extra
+		s.AllIn = false
+	}
+}
+
+// settle builds the main and side pots, awards each to the best eligible
+// hands in the results, and ends the hand
+func (t *bettingTable) settle(oddChip OddChipRule) {
+	t.returnUncalled()
+
+	var contribs = make([]Contribution, len(t.seats))
+	for i, s := range t.seats {
+		if s != nil {
+			contribs[i] = Contribution{Amount: s.Total, Folded: !s.inHand()}
+		}
+	}
+	var results = t.results
+	if results == nil {
+		results = make([]*HandResult, len(t.seats))
+	}
+	t.won = Distribute(BuildPots(contribs), results, oddChip)
+
+	for i, s := range t.seats {
+		if s != nil {
+			s.Stack += t.won[i]
+		}
+	}
+	t.inProgress = false
+	t.toAct = -1
+}
@@ -0,0 +1,150 @@
+package poker
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestRaiseLimits(t *testing.T) {
+	var fl = FixedLimit{SmallBet: 10, BigBet: 20, MaxRaises: 4}
+	var tests = map[string]struct {
+		structure BettingStructure
+		state     BettingState
+		min, max  int
+	}{
+		"No-limit open":         {NoLimit{}, BettingState{CurrentBet: 10, LastRaise: 10, Pot: 15, Stack: 1000}, 20, 1000},
+		"No-limit reraise":      {NoLimit{}, BettingState{CurrentBet: 60, LastRaise: 40, Pot: 200, PlayerBet: 20, Stack: 500}, 100, 520},
+		"Pot-limit open":        {PotLimit{}, BettingState{CurrentBet: 10, LastRaise: 10, Pot: 15, Stack: 1000}, 20, 35},
+		"Pot-limit small blind": {PotLimit{}, BettingState{CurrentBet: 10, LastRaise: 10, Pot: 15, PlayerBet: 5, Stack: 1000}, 20, 30},
+		"Pot-limit bet":         {PotLimit{}, BettingState{LastRaise: 10, Pot: 100, Stack: 1000}, 10, 100},
+		"Pot-limit raise":       {PotLimit{}, BettingState{CurrentBet: 50, LastRaise: 50, Pot: 150, Stack: 1000}, 100, 250},
+		"Fixed-limit preflop":   {fl, BettingState{CurrentBet: 10, LastRaise: 10, Raises: 1}, 20, 20},
+		"Fixed-limit flop":      {fl, BettingState{LastRaise: 10}, 10, 10},
+		"Fixed-limit turn":      {fl, BettingState{BigBets: true, CurrentBet: 20, LastRaise: 20, Raises: 1}, 40, 40},
+		"Fixed-limit capped":    {fl, BettingState{BigBets: true, CurrentBet: 80, LastRaise: 20, Raises: 4}, 0, 0},
+		"Uncapped":              {FixedLimit{SmallBet: 10, BigBet: 20}, BettingState{BigBets: true, CurrentBet: 200, Raises: 10}, 220, 220},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var min, max = tc.structure.RaiseLimits(tc.state)
+			if min != tc.min || max != tc.max {
+				t.Errorf("Expected limits %d to %d, got %d to %d", tc.min, tc.max, min, max)
+			}
+		})
+	}
+}
+
+// newStructuredTable is newTestTable for other games: the structure and hole
+// cards are set before the first hand starts
+func newStructuredTable(t *testing.T, bs BettingStructure, holeCards int, stacks ...int) *Table {
+	t.Helper()
+	var table, _ = NewTable(len(stacks), 5, 10, rand.NewSource(1))
+	for i, stack := range stacks {
+		table.Sit(i, string(rune('A'+i)), stack)
+	}
+	var err = table.SetBettingStructure(bs)
+	if err == nil {
+		err = table.SetHoleCards(holeCards)
+	}
+	if err == nil {
+		err = table.StartHand()
+	}
+	if err != nil {
+		t.Fatalf("Unable to set up table: %s", err)
+	}
+	return table
+}
+
+func TestTablePotLimitOmaha(t *testing.T) {
+	var table = newStructuredTable(t, PotLimit{}, 4, 1000, 1000, 1000)
+	if len(seat(t, table, 0).Hand.cards) != 4 {
+		t.Fatalf("Expected four hole cards, got %s", seat(t, table, 0).Hand)
+	}
+
+	var legal = table.Legal()
+	if legal.MinRaise != 20 || legal.MaxRaise != 35 {
+		t.Fatalf("Expected to raise from 20 to 35, got %+v", legal)
+	}
+	var err = table.Act(raiseTo(36))
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected an overbet to be illegal, got %v", err)
+	}
+
+	// Pot is 50 after the raise to 35, so the small blind can call 30 and
+	// raise 80 more, to 115
+	mustAct(t, table, raiseTo(35))
+	legal = table.Legal()
+	if legal.MaxRaise != 115 {
+		t.Errorf("Expected the small blind to raise to at most 115, got %+v", legal)
+	}
+	mustAct(t, table, call, call)
+
+	// 105 in the pot on the flop
+	legal = table.Legal()
+	if legal.MinRaise != 10 || legal.MaxRaise != 105 {
+		t.Errorf("Expected to bet 10 to 105 on the flop, got %+v", legal)
+	}
+	for table.InProgress() {
+		mustAct(t, table, check)
+	}
+
+	for i, hr := range table.Results() {
+		if len(hr.Hand) != 4 || len(hr.Best5) != 5 {
+			t.Errorf("Expected seat %d to have an Omaha result, got %v", i, hr)
+		}
+	}
+}
+
+func TestTableFixedLimit(t *testing.T) {
+	var table = newStructuredTable(t, FixedLimit{SmallBet: 10, BigBet: 20, MaxRaises: 4}, 2, 1000, 1000, 1000)
+
+	var legal = table.Legal()
+	if legal.MinRaise != 20 || legal.MaxRaise != 20 {
+		t.Fatalf("Expected a raise to exactly 20, got %+v", legal)
+	}
+	var err = table.Act(raiseTo(30))
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected raising to 30 to be illegal, got %v", err)
+	}
+
+	// Blind, raise, reraise, cap
+	mustAct(t, table, raiseTo(20), raiseTo(30), raiseTo(40))
+	legal = table.Legal()
+	if legal.MaxRaise != 0 || legal.Call != 20 {
+		t.Fatalf("Expected the betting to be capped, got %+v", legal)
+	}
+	mustAct(t, table, call, call)
+
+	// Small bets on the flop, big bets on the turn
+	if table.Street() != Flop || table.Legal().MinRaise != 10 || table.Legal().MaxRaise != 10 {
+		t.Fatalf("Expected a 10-chip bet on the flop, got %+v on the %s", table.Legal(), table.Street())
+	}
+	mustAct(t, table, betTo(10), call, call)
+	if table.Street() != Turn || table.Legal().MinRaise != 20 {
+		t.Fatalf("Expected a 20-chip bet on the turn, got %+v on the %s", table.Legal(), table.Street())
+	}
+}
+
+func TestTableSetupErrors(t *testing.T) {
+	var table, _ = NewTable(22, 5, 10, rand.NewSource(1))
+	var err = table.SetHoleCards(3)
+	if !errors.Is(err, ErrInvalidCardCount) {
+		t.Errorf("Expected three hole cards to be %q, got %v", ErrInvalidCardCount, err)
+	}
+	err = table.SetHoleCards(4)
+	if !errors.Is(err, ErrInvalidCardCount) {
+		t.Errorf("Expected 22 Omaha hands to be %q, got %v", ErrInvalidCardCount, err)
+	}
+
+	table = newTestTable(t, 1, 1000, 1000)
+	err = table.SetHoleCards(4)
+	if !errors.Is(err, ErrHandInProgress) {
+		t.Errorf("Expected changing hole cards mid-hand to be %q, got %v", ErrHandInProgress, err)
+	}
+	err = table.SetBettingStructure(PotLimit{})
+	if !errors.Is(err, ErrHandInProgress) {
+		t.Errorf("Expected changing structure mid-hand to be %q, got %v", ErrHandInProgress, err)
+	}
+}
@@ -0,0 +1,234 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SuitTexture describes how the suits on a board are spread out
+type SuitTexture int
+
+// All suit textures, by how many suits are on the board: a monotone board has
+// one, a two-tone board has two, and a three-tone turn or river has three. A
+// rainbow flop has three, and a rainbow turn or river has all four.
+const (
+	Rainbow SuitTexture = iota + 1
+	TwoTone
+	Monotone
+	ThreeTone
+)
+
+func (st SuitTexture) String() string {
+	switch st {
+	case Rainbow:
+		return "Rainbow"
+	case TwoTone:
+		return "Two-Tone"
+	case ThreeTone:
+		return "Three-Tone"
+	case Monotone:
+		return "Monotone"
+	}
+
+	return ""
+}
+
+// Board wraps the community cards in a Hold 'em game and reports on their
+// texture: pairs, suits, how straight-friendly the ranks are, and the best
+// hand anybody could hold
+type Board struct {
+	cards      CardList
+	rankCounts [13]int
+	suitCounts [4]int
+
+	// nutScore is worked out the first time it's needed, since it means
+	// trying every pair of hole cards
+	nutScore uint16
+}
+
+// NewBoard returns a Board for a three-, four-, or five-card board
+func NewBoard(cards CardList) (*Board, error) {
+	if len(cards) < 3 || len(cards) > 5 {
+		return nil, fmt.Errorf("%w: board must be three to five cards", ErrInvalidCardCount)
+	}
+	var _, err = remainingCards(cards)
+	if err != nil {
+		return nil, err
+	}
+
+	var b = &Board{cards: append(CardList(nil), cards...)}
+	for _, c := range cards {
+		b.rankCounts[c.Rank()]++
+		b.suitCounts[c.index()%4]++
+	}
+
+	return b, nil
+}
+
+// Cards returns a copy of the board's cards
+func (b *Board) Cards() CardList {
+	return append(CardList(nil), b.cards...)
+}
+
+// maxRankCount returns the most cards of any one rank
+func (b *Board) maxRankCount() int {
+	var most int
+	for _, n := range b.rankCounts {
+		if n > most {
+			most = n
+		}
+	}
+	return most
+}
+
+// Paired returns true if at least two cards share a rank
+func (b *Board) Paired() bool {
+	return b.maxRankCount() >= 2
+}
+
+// DoublePaired returns true if two different ranks are each paired (or
+// better) on the board
+func (b *Board) DoublePaired() bool {
+	var pairs int
+	for _, n := range b.rankCounts {
+		if n >= 2 {
+			pairs++
+		}
+	}
+	return pairs >= 2
+}
+
+// Trips returns true if at least three cards share a rank
+func (b *Board) Trips() bool {
+	return b.maxRankCount() >= 3
+}
+
+// MaxSuitCount returns the most cards of any one suit
+func (b *Board) MaxSuitCount() int {
+	var most int
+	for _, n := range b.suitCounts {
+		if n > most {
+			most = n
+		}
+	}
+	return most
+}
+
+// SuitTexture returns whether the board is rainbow, three-tone, two-tone, or
+// monotone
+func (b *Board) SuitTexture() SuitTexture {
+	var suits int
+	for _, n := range b.suitCounts {
+		if n > 0 {
+			suits++
+		}
+	}
+
+	switch {
+	case suits == 1:
+		return Monotone
+	case suits == 2:
+		return TwoTone
+	case suits == 3 && len(b.cards) > 3:
+		return ThreeTone
+	}
+	return Rainbow
+}
+
+// FlushPossible returns true if a player could already have a flush, i.e.,
+// there are at least three cards of one suit
+func (b *Board) FlushPossible() bool {
+	return b.MaxSuitCount() >= 3
+}
+
+// straightWindows returns how many board ranks fall in each of the ten
+// possible straights, from the wheel up to broadway
+func (b *Board) straightWindows() [10]int {
+	var windows [10]int
+	for low := 0; low < 10; low++ {
+		for i := low; i < low+5; i++ {
+			// The wheel's bottom card is the ace
+			var r = Ace
+			if i > 0 {
+				r = CardRank(i - 1)
+			}
+			if b.rankCounts[r] > 0 {
+				windows[low]++
+			}
+		}
+	}
+	return windows
+}
+
+// StraightCount returns how many different straights a player could already
+// have, using two hole cards, e.g., 7-8-9 allows three: 5-9, 6-T, and 7-J
+func (b *Board) StraightCount() int {
+	var count int
+	for _, n := range b.straightWindows() {
+		if n >= 3 {
+			count++
+		}
+	}
+	return count
+}
+
+// StraightPossible returns true if a player could already have a straight
+func (b *Board) StraightPossible() bool {
+	return b.StraightCount() > 0
+}
+
+// Connectedness returns the most distinct board ranks which fit into a single
+// straight: 1 means no two cards could ever be part of the same straight,
+// while 3 or more means a straight is already possible
+func (b *Board) Connectedness() int {
+	var most int
+	for _, n := range b.straightWindows() {
+		if n > most {
+			most = n
+		}
+	}
+	return most
+}
+
+// NutScore returns the score of the best hand any two hole cards could make
+// with this board
+func (b *Board) NutScore() uint16 {
+	if b.nutScore == 0 {
+		// NewBoard already checked the cards, so this can't fail
+		var nuts, _ = Nuts(b.cards)
+		b.nutScore = nuts.Result.Score
+	}
+	return b.nutScore
+}
+
+// NutRank returns the rank of the best hand any two hole cards could make
+// with this board
+func (b *Board) NutRank() HandRank {
+	return GetHandRank(b.NutScore())
+}
+
+// Describe returns a short summary of the board's texture suitable for a hand
+// history, e.g., "Paired, Two-Tone, Straight Possible"
+func (b *Board) Describe() string {
+	var parts []string
+	switch {
+	case b.maxRankCount() == 4:
+		parts = append(parts, "Quads")
+	case b.Trips():
+		parts = append(parts, "Trips")
+	case b.DoublePaired():
+		parts = append(parts, "Double-Paired")
+	case b.Paired():
+		parts = append(parts, "Paired")
+	}
+
+	parts = append(parts, b.SuitTexture().String())
+	if b.StraightPossible() {
+		parts = append(parts, "Straight Possible")
+	}
+	if b.FlushPossible() {
+		parts = append(parts, "Flush Possible")
+	}
+
+	return strings.Join(parts, ", ")
+}
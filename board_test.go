@@ -0,0 +1,135 @@
+package poker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBoardTexture(t *testing.T) {
+	var tests = map[string]struct {
+		cards         string
+		texture       SuitTexture
+		straights     int
+		connectedness int
+		nuts          HandRank
+		describe      string
+	}{
+		"Broadway monotone": {"Ah Kh Qh", Monotone, 1, 3, StraightFlush, "Monotone, Straight Possible, Flush Possible"},
+		"Connected":         {"7c 8d 9h", Rainbow, 3, 3, Straight, "Rainbow, Straight Possible"},
+		"Dry":               {"Kd 7c 2s", Rainbow, 0, 1, ThreeOfAKind, "Rainbow"},
+		"Paired":            {"Ks Kd 7s", TwoTone, 0, 1, FourOfAKind, "Paired, Two-Tone"},
+		"Wheel-ish turn":    {"2c 3c 4d 5h", ThreeTone, 3, 4, Straight, "Three-Tone, Straight Possible"},
+		"Full board":        {"7h 7d 7c 2s 2h", Rainbow, 0, 1, FourOfAKind, "Trips, Rainbow"},
+		"Flush turn":        {"9s 5s 2s Kd", TwoTone, 0, 2, Flush, "Two-Tone, Flush Possible"},
+		"Rainbow turn":      {"As 4h 5d Tc", Rainbow, 1, 3, Straight, "Rainbow, Straight Possible"},
+		"Two-tone turn":     {"As 4h 5s Th", TwoTone, 1, 3, Straight, "Two-Tone, Straight Possible"},
+		"Rainbow river":     {"Ah Kh 2d 3c 4s", Rainbow, 2, 4, Straight, "Rainbow, Straight Possible"},
+		"Three-tone river":  {"Ah Kh 2d 3d 9c", ThreeTone, 1, 3, Straight, "Three-Tone, Straight Possible"},
+		"Two-tone river":    {"Ah Kh 2h 3c 9c", TwoTone, 1, 3, Flush, "Two-Tone, Straight Possible, Flush Possible"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cards, _ = ParseCards(tc.cards)
+			var b, err = NewBoard(cards)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if b.SuitTexture() != tc.texture {
+				t.Errorf("Expected %s to be %s, got %s", cards, tc.texture, b.SuitTexture())
+			}
+			if b.StraightCount() != tc.straights {
+				t.Errorf("Expected %s to allow %d straights, got %d", cards, tc.straights, b.StraightCount())
+			}
+			if b.Connectedness() != tc.connectedness {
+				t.Errorf("Expected %s to have connectedness %d, got %d", cards, tc.connectedness, b.Connectedness())
+			}
+			if b.NutRank() != tc.nuts {
+				t.Errorf("Expected the nuts on %s to be %s, got %s", cards, tc.nuts, b.NutRank())
+			}
+			if b.Describe() != tc.describe {
+				t.Errorf("Expected %s to be described as %q, got %q", cards, tc.describe, b.Describe())
+			}
+		})
+	}
+}
+
+func TestBoardPairing(t *testing.T) {
+	var tests = map[string]struct {
+		cards        string
+		paired       bool
+		doublePaired bool
+		trips        bool
+	}{
+		"Unpaired":      {"Ah Kd 7c", false, false, false},
+		"Paired":        {"Ah Ad 7c", true, false, false},
+		"Double-paired": {"Ah Ad 7c 7d", true, true, false},
+		"Trips":         {"Ah Ad Ac 7d", true, false, true},
+		"Full house":    {"Ah Ad Ac 7d 7s", true, true, true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cards, _ = ParseCards(tc.cards)
+			var b, _ = NewBoard(cards)
+			if b.Paired() != tc.paired || b.DoublePaired() != tc.doublePaired || b.Trips() != tc.trips {
+				t.Errorf("Expected %s to be paired=%v, double-paired=%v, trips=%v; got %v, %v, %v",
+					cards, tc.paired, tc.doublePaired, tc.trips, b.Paired(), b.DoublePaired(), b.Trips())
+			}
+		})
+	}
+}
+
+func TestBoardNutScore(t *testing.T) {
+	// A royal flush on the board is everybody's nuts
+	var cards, _ = ParseCards("Ah Kh Qh Jh Th")
+	var b, _ = NewBoard(cards)
+	if b.NutScore() != 1 {
+		t.Errorf("Expected the nuts to score 1, got %d", b.NutScore())
+	}
+
+	// The board's own cards are copied, not shared
+	b.Cards()[0] = cards[1]
+	if b.Cards()[0] != cards[0] {
+		t.Errorf("Expected Cards to return a copy")
+	}
+}
+
+func TestBoardErrors(t *testing.T) {
+	var tests = map[string]struct {
+		cards string
+		err   error
+	}{
+		"Two cards": {"Ah Kh", ErrInvalidCardCount},
+		"Six cards": {"Ah Kh Qh Jh Th 9h", ErrInvalidCardCount},
+		"Duplicate": {"Ah Kh Ah", ErrDuplicateCard},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cards, _ = ParseCards(tc.cards)
+			var _, err = NewBoard(cards)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %q, got %v", tc.err, err)
+			}
+		})
+	}
+	// The zero Card isn't a card at all
+	var cards, _ = ParseCards("Ah Kh 2c")
+	cards[0] = 0
+	var _, err = NewBoard(cards)
+	if !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("Expected a zero card to be %q, got %v", ErrInvalidCard, err)
+	}
+}
+
+func TestBoardNutsLazy(t *testing.T) {
+	var cards, _ = ParseCards("Ah Kh Qh")
+	var b, _ = NewBoard(cards)
+	if b.Paired() || b.SuitTexture() != Monotone || b.nutScore != 0 {
+		t.Fatalf("Expected the nuts not to be worked out for the texture")
+	}
+	if b.NutRank() != StraightFlush || b.nutScore == 0 {
+		t.Errorf("Expected the nuts to be a straight flush, got %s", b.NutRank())
+	}
+}
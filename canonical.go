@@ -0,0 +1,501 @@
+package poker
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"sync"
+)
+
+// Suits don't matter in hold'em except for how cards share them, so AhKh on a
+// 2h 7c 9d flop plays exactly the same as AsKs on 2s 7h 9c. These functions
+// collapse situations like that into one canonical form, and give each form a
+// dense index which can key a cache or a strategy table.
+//
+// This follows Kevin Waugh's approach to hand isomorphism: the cards dealt in
+// each round (hole cards, flop, turn, river) are kept separate, each suit is
+// described by the ranks it has in each round, and the suits are then sorted
+// so that the order they started in doesn't matter.
+
+// isoRounds are the number of cards dealt in each betting round, indexed by
+// how many board cards have been dealt so far
+var isoRounds = map[int][]int{
+	0: {2},
+	3: {2, 3},
+	4: {2, 3, 1},
+	5: {2, 3, 1, 1},
+}
+
+// isoGroup is a run of suits in a configuration which all have the same
+// number of cards in every round
+type isoGroup struct {
+	shape    int
+	count    int
+	suitSize uint64
+	size     uint64
+}
+
+// isoConfig is one way of spreading each round's cards across four suits,
+// e.g., preflop is either two cards of one suit or one card each of two suits
+type isoConfig struct {
+	key    uint32
+	groups []isoGroup
+	offset uint64
+	size   uint64
+}
+
+// isoIndexer holds all the configurations for a number of board cards
+type isoIndexer struct {
+	rounds  []int
+	configs []isoConfig
+	byKey   map[uint32]int
+	total   uint64
+}
+
+var isoIndexers = make(map[int]*isoIndexer)
+var isoIndexersMu sync.Mutex
+
+// getIsoIndexer returns the indexer for the given board size, building it the
+// first time it's needed
+func getIsoIndexer(boardSize int) (*isoIndexer, error) {
+	var rounds, ok = isoRounds[boardSize]
+	if !ok {
+		return nil, fmt.Errorf("%w: board must be zero, three, four, or five cards", ErrInvalidCardCount)
+	}
+
+	isoIndexersMu.Lock()
+	defer isoIndexersMu.Unlock()
+	if isoIndexers[boardSize] == nil {
+		isoIndexers[boardSize] = newIsoIndexer(rounds)
+	}
+	return isoIndexers[boardSize], nil
+}
+
+func newIsoIndexer(rounds []int) *isoIndexer {
+	var ix = &isoIndexer{rounds: rounds, byKey: make(map[uint32]int)}
+
+	// Every possible shape of a single suit, from most cards in the earliest
+	// rounds down to none at all
+	var shapes []int
+	var build func(r, code int)
+	build = func(r, code int) {
+		if r == len(rounds) {
+			shapes = append(shapes, code)
+			return
+		}
+		for n := 0; n <= rounds[r]; n++ {
+			build(r+1, code<<2|n)
+		}
+	}
+	build(0, 0)
+	sort.Sort(sort.Reverse(sort.IntSlice(shapes)))
+
+	// Every non-increasing list of four shapes which adds up to the right
+	// number of cards in each round is a configuration
+	var chosen = make([]int, 0, 4)
+	var walk func(start int, left []int)
+	walk = func(start int, left []int) {
+		if len(chosen) == 4 {
+			for _, n := range left {
+				if n != 0 {
+					return
+				}
+			}
+			ix.addConfig(chosen)
+			return
+		}
+		for i := start; i < len(shapes); i++ {
+			var counts = ix.shapeCounts(shapes[i])
+			var fits = true
+			var rest = make([]int, len(left))
+			for r, n := range counts {
+				rest[r] = left[r] - n
+				if rest[r] < 0 {
+					fits = false
+				}
+			}
+			if fits {
+				chosen = append(chosen, shapes[i])
+				walk(i, rest)
+				chosen = chosen[:len(chosen)-1]
+			}
+		}
+	}
+	walk(0, rounds)
+
+	return ix
+}
+
+// addConfig records a configuration and its place in the index
+func (ix *isoIndexer) addConfig(shapes []int) {
+	var cfg = isoConfig{key: isoKey(shapes), offset: ix.total, size: 1}
+	for i := 0; i < len(shapes); {
+		var j = i
+		for j < len(shapes) && shapes[j] == shapes[i] {
+			j++
+		}
+		var g = isoGroup{shape: shapes[i], count: j - i, suitSize: ix.suitSize(shapes[i])}
+		g.size = choose(g.suitSize+uint64(g.count)-1, uint64(g.count))
+		cfg.groups = append(cfg.groups, g)
+		cfg.size *= g.size
+		i = j
+	}
+
+	ix.byKey[cfg.key] = len(ix.configs)
+	ix.configs = append(ix.configs, cfg)
+	ix.total += cfg.size
+}
+
+// isoKey packs four shape codes into a single map key
+func isoKey(shapes []int) uint32 {
+	var key uint32
+	for _, s := range shapes {
+		key = key<<8 | uint32(s)
+	}
+	return key
+}
+
+// shapeCounts unpacks a shape code into the number of cards in each round
+func (ix *isoIndexer) shapeCounts(shape int) []int {
+	var counts = make([]int, len(ix.rounds))
+	for r := len(ix.rounds) - 1; r >= 0; r-- {
+		counts[r] = shape & 3
+		shape >>= 2
+	}
+	return counts
+}
+
+// suitSize returns how many different ways a single suit can have the given
+// shape: the ways to pick its first round's ranks, times the ways to pick the
+// next round's from what's left, and so on
+func (ix *isoIndexer) suitSize(shape int) uint64 {
+	var size uint64 = 1
+	var free uint64 = 13
+	for _, n := range ix.shapeCounts(shape) {
+		size *= choose(free, uint64(n))
+		free -= uint64(n)
+	}
+	return size
+}
+
+// choose returns n-choose-k. It's only ever used where the result (and every
+// step along the way) fits comfortably in 64 bits.
+func choose(n, k uint64) uint64 {
+	if k > n {
+		return 0
+	}
+	var result uint64 = 1
+	for i := uint64(1); i <= k; i++ {
+		result = result * (n - k + i) / i
+	}
+	return result
+}
+
+// suitIndex ranks a single suit's cards, given its rank masks for each round,
+// among all suits of the same shape
+func suitIndex(masks []uint32) uint64 {
+	var idx uint64
+	var used uint32
+	for _, m := range masks {
+		var free = 13 - bits.OnesCount32(used)
+		var n = bits.OnesCount32(m)
+		idx = idx*choose(uint64(free), uint64(n)) + colex(compressRanks(m, used))
+		used |= m
+	}
+	return idx
+}
+
+// compressRanks removes the used ranks from mask, sliding the remaining
+// ranks down to fill the gaps
+func compressRanks(mask, used uint32) uint32 {
+	var out uint32
+	var pos uint
+	for r := uint(0); r < 13; r++ {
+		if used&(1<<r) != 0 {
+			continue
+		}
+		if mask&(1<<r) != 0 {
+			out |= 1 << pos
+		}
+		pos++
+	}
+	return out
+}
+
+// expandRanks undoes compressRanks
+func expandRanks(compressed, used uint32) uint32 {
+	var out uint32
+	var pos uint
+	for r := uint(0); r < 13; r++ {
+		if used&(1<<r) != 0 {
+			continue
+		}
+		if compressed&(1<<pos) != 0 {
+			out |= 1 << r
+		}
+		pos++
+	}
+	return out
+}
+
+// colex returns a set of bits' position in colexicographic order among all
+// sets of the same size
+func colex(mask uint32) uint64 {
+	var idx uint64
+	var k uint64
+	for ; mask != 0; mask &= mask - 1 {
+		k++
+		idx += choose(uint64(bits.TrailingZeros32(mask)), k)
+	}
+	return idx
+}
+
+// uncolex returns the set of n bits at the given colexicographic position
+func uncolex(idx uint64, n int) uint32 {
+	var mask uint32
+	for k := uint64(n); k > 0; k-- {
+		var p = k - 1
+		for choose(p+1, k) <= idx {
+			p++
+		}
+		mask |= 1 << p
+		idx -= choose(p, k)
+	}
+	return mask
+}
+
+// multisetIndex returns the position of a sorted (largest first) list of
+// values among all such lists of the same length
+func multisetIndex(values []uint64) uint64 {
+	var idx uint64
+	var k = uint64(len(values))
+	for i, v := range values {
+		var j = k - uint64(i)
+		idx += choose(v+j-1, j)
+	}
+	return idx
+}
+
+// multisetValues undoes multisetIndex
+func multisetValues(idx uint64, count int) []uint64 {
+	var values = make([]uint64, count)
+	for i := range values {
+		var j = uint64(count - i)
+		var w = j - 1
+		if j == 1 {
+			w = idx
+		} else {
+			for choose(w+1, j) <= idx {
+				w++
+			}
+		}
+		idx -= choose(w, j)
+		values[i] = w - (j - 1)
+	}
+	return values
+}
+
+// isoSuit is everything we need to know about one suit in a hand
+type isoSuit struct {
+	suit  CardSuit
+	masks []uint32
+	shape int
+	index uint64
+}
+
+// CanonicalIndex returns a dense index for the two hole cards and zero, three,
+// four, or five board cards. Any two situations which are the same except for
+// how the suits are named get the same index, and the index is always less
+// than CanonicalCount for that board size: 169 preflop, 1,286,792 on the
+// flop, 55,190,538 on the turn, and 2,428,287,420 on the river.
+func CanonicalIndex(hole, board CardList) (uint64, error) {
+	var ix, suits, err = isoSuits(hole, board)
+	if err != nil {
+		return 0, err
+	}
+
+	var shapes = make([]int, len(suits))
+	for i, s := range suits {
+		shapes[i] = s.shape
+	}
+	var cfg = ix.configs[ix.byKey[isoKey(shapes)]]
+
+	var local uint64
+	var pos int
+	for _, g := range cfg.groups {
+		var values = make([]uint64, g.count)
+		for i := range values {
+			values[i] = suits[pos+i].index
+		}
+		pos += g.count
+		local = local*g.size + multisetIndex(values)
+	}
+
+	return cfg.offset + local, nil
+}
+
+// isoSuits validates the cards and describes each suit, sorted into the order
+// CanonicalIndex uses
+func isoSuits(hole, board CardList) (*isoIndexer, []isoSuit, error) {
+	if len(hole) != 2 {
+		return nil, nil, fmt.Errorf("%w: need exactly two hole cards", ErrInvalidCardCount)
+	}
+	var ix, err = getIsoIndexer(len(board))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var seen CardSet
+	var rounds = make([]CardList, len(ix.rounds))
+	rounds[0] = hole
+	var start int
+	for r := 1; r < len(rounds); r++ {
+		rounds[r] = board[start : start+ix.rounds[r]]
+		start += ix.rounds[r]
+	}
+
+	var suits = make([]isoSuit, len(allSuits))
+	for i, s := range allSuits {
+		suits[i] = isoSuit{suit: s, masks: make([]uint32, len(rounds))}
+	}
+	for r, cards := range rounds {
+		for _, c := range cards {
+			if c.index() < 0 {
+				return nil, nil, fmt.Errorf("%w: %s", ErrInvalidCard, c)
+			}
+			if seen.Contains(c) {
+				return nil, nil, fmt.Errorf("%w: %s", ErrDuplicateCard, c)
+			}
+			seen.Add(c)
+			suits[c.index()%4].masks[r] |= 1 << c.Rank()
+		}
+	}
+
+	for i := range suits {
+		for _, m := range suits[i].masks {
+			suits[i].shape = suits[i].shape<<2 | bits.OnesCount32(m)
+		}
+		suits[i].index = suitIndex(suits[i].masks)
+	}
+	sort.SliceStable(suits, func(i, j int) bool {
+		if suits[i].shape != suits[j].shape {
+			return suits[i].shape > suits[j].shape
+		}
+		return suits[i].index > suits[j].index
+	})
+
+	return ix, suits, nil
+}
+
+// CanonicalHand is the inverse of CanonicalIndex: it returns the canonical
+// hole cards and board for an index. Suits are handed out in order (spades,
+// hearts, diamonds, then clubs) to the suits which matter most, and each
+// round's cards are sorted by rank, so the results are always the same for
+// the same index.
+func CanonicalHand(index uint64, boardSize int) (hole, board CardList, err error) {
+	var ix *isoIndexer
+	ix, err = getIsoIndexer(boardSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	if index >= ix.total {
+		return nil, nil, fmt.Errorf("canonical index %d is out of range for a %d-card board", index, boardSize)
+	}
+
+	var ci = sort.Search(len(ix.configs), func(i int) bool {
+		return ix.configs[i].offset > index
+	}) - 1
+	var cfg = ix.configs[ci]
+
+	// Pull each group's part of the index back out, last group first
+	var local = index - cfg.offset
+	var groupIdx = make([]uint64, len(cfg.groups))
+	for i := len(cfg.groups) - 1; i >= 0; i-- {
+		groupIdx[i] = local % cfg.groups[i].size
+		local /= cfg.groups[i].size
+	}
+
+	var rounds = make([]CardList, len(ix.rounds))
+	var suit int
+	for i, g := range cfg.groups {
+		var counts = ix.shapeCounts(g.shape)
+		for _, v := range multisetValues(groupIdx[i], g.count) {
+			for r, m := range suitMasks(v, counts) {
+				for rank := Ace; m != 0; rank-- {
+					if m&(1<<rank) != 0 {
+						rounds[r] = append(rounds[r], NewCard(rank, allSuits[suit]))
+						m &^= 1 << rank
+					}
+				}
+			}
+			suit++
+		}
+	}
+
+	for _, cards := range rounds {
+		sortCardsByRank(cards)
+	}
+	hole = rounds[0]
+	board = CardList{}
+	for _, cards := range rounds[1:] {
+		board = append(board, cards...)
+	}
+	return hole, board, nil
+}
+
+// suitMasks undoes suitIndex, given how many cards the suit has each round
+func suitMasks(idx uint64, counts []int) []uint32 {
+	var radix = make([]uint64, len(counts))
+	var free uint64 = 13
+	for r, n := range counts {
+		radix[r] = choose(free, uint64(n))
+		free -= uint64(n)
+	}
+
+	var compressed = make([]uint32, len(counts))
+	for r := len(counts) - 1; r >= 0; r-- {
+		compressed[r] = uncolex(idx%radix[r], counts[r])
+		idx /= radix[r]
+	}
+
+	var masks = make([]uint32, len(counts))
+	var used uint32
+	for r := range counts {
+		masks[r] = expandRanks(compressed[r], used)
+		used |= masks[r]
+	}
+	return masks
+}
+
+// sortCardsByRank puts the cards in order from highest rank to lowest, with
+// ties broken by suit in deck order
+func sortCardsByRank(cards CardList) {
+	sort.Slice(cards, func(i, j int) bool {
+		if cards[i].Rank() != cards[j].Rank() {
+			return cards[i].Rank() > cards[j].Rank()
+		}
+		return cards[i].Suit() < cards[j].Suit()
+	})
+}
+
+// Canonicalize returns the canonical form of the hole cards and board: the
+// same cards with their suits renamed so that any two equivalent situations
+// give exactly the same result
+func Canonicalize(hole, board CardList) (CardList, CardList, error) {
+	var idx, err = CanonicalIndex(hole, board)
+	if err != nil {
+		return nil, nil, err
+	}
+	return CanonicalHand(idx, len(board))
+}
+
+// CanonicalCount returns the number of canonical situations for a board of
+// zero, three, four, or five cards. Every index from CanonicalIndex is below
+// this number, and every number below it is a valid index.
+func CanonicalCount(boardSize int) (uint64, error) {
+	var ix, err = getIsoIndexer(boardSize)
+	if err != nil {
+		return 0, err
+	}
+	return ix.total, nil
+}
@@ -0,0 +1,216 @@
+package poker
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestCanonicalCount(t *testing.T) {
+	var tests = map[int]uint64{
+		0: 169,
+		3: 1286792,
+		4: 55190538,
+		5: 2428287420,
+	}
+
+	for boardSize, expected := range tests {
+		var got, err = CanonicalCount(boardSize)
+		if err != nil {
+			t.Fatalf("Unexpected error for a %d-card board: %s", boardSize, err)
+		}
+		if got != expected {
+			t.Errorf("Expected %d canonical situations with a %d-card board, got %d", expected, boardSize, got)
+		}
+	}
+
+	var _, err = CanonicalCount(2)
+	if !errors.Is(err, ErrInvalidCardCount) {
+		t.Errorf("Expected a two-card board to be invalid, got %v", err)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	var tests = map[string]struct {
+		hole      string
+		board     string
+		canonHole string
+		canonComm string
+	}{
+		"Suited, two-tone flop": {"Ah Kh", "2h 7c 9d", "As Ks", "9h 7d 2s"},
+		"Same, other suits":     {"As Ks", "2s 7h 9c", "As Ks", "9h 7d 2s"},
+		"Offsuit":               {"Kd Ah", "", "As Kh", ""},
+		"Pair":                  {"Tc Td", "", "Ts Th", ""},
+		"River":                 {"Ah Kh", "Qh Jh 2c 3d Th", "As Ks", "Qs Js 2h 3d Ts"},
+		"Suit only on turn":     {"2c 3c", "4d 5d 6d 7h", "3s 2s", "6h 5h 4h 7d"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hole, _ = ParseCards(tc.hole)
+			var board, _ = ParseCards(tc.board)
+			var ch, cb, err = Canonicalize(hole, board)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if ch.String() != tc.canonHole || cb.String() != tc.canonComm {
+				t.Errorf("Expected %s / %s to become %q / %q, got %q / %q", hole, board, tc.canonHole, tc.canonComm, ch, cb)
+			}
+		})
+	}
+}
+
+// permuteSuits returns a copy of the cards with suits swapped around
+func permuteSuits(cards CardList, perm []int) CardList {
+	var out = make(CardList, len(cards))
+	for i, c := range cards {
+		out[i] = NewCard(c.Rank(), allSuits[perm[c.index()%4]])
+	}
+	return out
+}
+
+func TestCanonicalIndexIsomorphic(t *testing.T) {
+	var rnd = rand.New(rand.NewSource(1))
+	var deck = NewDeck(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		var boardSize = []int{0, 3, 4, 5}[i%4]
+		deck.Reset()
+		deck.Shuffle()
+		var hole, board = deck.Draw(2), deck.Draw(boardSize)
+
+		var idx, err = CanonicalIndex(hole, board)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s / %s: %s", hole, board, err)
+		}
+		var count, _ = CanonicalCount(boardSize)
+		if idx >= count {
+			t.Fatalf("%s / %s: index %d is out of range", hole, board, idx)
+		}
+
+		// Renaming suits, or shuffling the cards within a round, can't change
+		// anything
+		var perm = rnd.Perm(4)
+		var h2, b2 = permuteSuits(hole, perm), permuteSuits(board, perm)
+		h2[0], h2[1] = h2[1], h2[0]
+		if boardSize >= 3 {
+			b2[0], b2[2] = b2[2], b2[0]
+		}
+		var idx2, _ = CanonicalIndex(h2, b2)
+		if idx != idx2 {
+			t.Fatalf("%s / %s has index %d, but %s / %s has %d", hole, board, idx, h2, b2, idx2)
+		}
+	}
+}
+
+func TestCanonicalIndexDistinguishesRounds(t *testing.T) {
+	// Same seven cards, but the flush card comes on the turn instead of the
+	// flop, which is a different situation
+	var hole, _ = ParseCards("Ah Kh")
+	var b1, _ = ParseCards("2h 7c 9d 3c")
+	var b2, _ = ParseCards("3c 7c 9d 2h")
+	var i1, _ = CanonicalIndex(hole, b1)
+	var i2, _ = CanonicalIndex(hole, b2)
+	if i1 == i2 {
+		t.Errorf("Expected %s and %s to have different indices", b1, b2)
+	}
+}
+
+func TestCanonicalPreflop(t *testing.T) {
+	var deck = NewDeck(rand.NewSource(0)).cards
+	var seen = make(map[uint64]bool)
+	for a := 0; a < 52; a++ {
+		for b := a + 1; b < 52; b++ {
+			var idx, err = CanonicalIndex(CardList{deck[a], deck[b]}, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			seen[idx] = true
+		}
+	}
+	if len(seen) != 169 {
+		t.Fatalf("Expected 169 preflop classes, got %d", len(seen))
+	}
+	for i := uint64(0); i < 169; i++ {
+		if !seen[i] {
+			t.Fatalf("No hole cards had index %d", i)
+		}
+	}
+}
+
+func TestCanonicalHandRoundTrip(t *testing.T) {
+	var rnd = rand.New(rand.NewSource(1))
+	for _, boardSize := range []int{0, 3, 4, 5} {
+		var count, _ = CanonicalCount(boardSize)
+		for i := 0; i < 500; i++ {
+			var idx = uint64(rnd.Int63n(int64(count)))
+			if i == 0 {
+				idx = 0
+			}
+			if i == 1 {
+				idx = count - 1
+			}
+
+			var hole, board, err = CanonicalHand(idx, boardSize)
+			if err != nil {
+				t.Fatalf("Unexpected error for index %d: %s", idx, err)
+			}
+			if len(hole) != 2 || len(board) != boardSize {
+				t.Fatalf("Index %d gave %s / %s", idx, hole, board)
+			}
+			var got, _ = CanonicalIndex(hole, board)
+			if got != idx {
+				t.Fatalf("Index %d gave %s / %s, which has index %d", idx, hole, board, got)
+			}
+
+			// The canonical hand must already be in canonical form
+			var h2, b2, _ = Canonicalize(hole, board)
+			if h2.String() != hole.String() || b2.String() != board.String() {
+				t.Fatalf("Index %d gave %s / %s, but that canonicalizes to %s / %s", idx, hole, board, h2, b2)
+			}
+		}
+	}
+}
+
+func TestCanonicalErrors(t *testing.T) {
+	var tests = map[string]struct {
+		hole  string
+		board string
+		err   error
+	}{
+		"One hole card":   {"Ah", "2c 3c 4c", ErrInvalidCardCount},
+		"Four hole cards": {"Ah Kh Qh Jh", "2c 3c 4c", ErrInvalidCardCount},
+		"Two-card board":  {"Ah Kh", "2c 3c", ErrInvalidCardCount},
+		"Duplicate":       {"Ah Kh", "2c 3c Ah", ErrDuplicateCard},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hole, _ = ParseCards(tc.hole)
+			var board, _ = ParseCards(tc.board)
+			var _, err = CanonicalIndex(hole, board)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %q, got %v", tc.err, err)
+			}
+		})
+	}
+
+	var _, _, err = CanonicalHand(169, 0)
+	if err == nil {
+		t.Fatalf("Expected an error for an out-of-range index")
+	}
+
+	// The zero Card isn't a card at all
+	var hole, _ = ParseCards("Ah Kh")
+	var board, _ = ParseCards("2c 3c 4c")
+	hole[0] = 0
+	_, err = CanonicalIndex(hole, board)
+	if !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("Expected a zero hole card to be %q, got %v", ErrInvalidCard, err)
+	}
+	hole, _ = ParseCards("Ah Kh")
+	board[2] = 0
+	_, _, err = Canonicalize(hole, board)
+	if !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("Expected a zero board card to be %q, got %v", ErrInvalidCard, err)
+	}
+}
@@ -1,6 +1,9 @@
 package poker
 
-import "fmt"
+import (
+	"fmt"
+	"math/bits"
+)
 
 // Card represents a unique card from a standard 52-card deck
 type Card uint32
@@ -234,3 +237,24 @@ func (c Card) Rank() CardRank {
 func (c Card) Suit() CardSuit {
 	return CardSuit((uint32(c) >> 12) & 0xF)
 }
+
+// Suits in the same order Deck uses them
+var allSuits = []CardSuit{Spades, Hearts, Diamonds, Clubs}
+
+// index returns a number from 0 to 51 which is unique to this card, in the
+// same order a new Deck is built: 2s, 2h, 2d, 2c, 3s, and so on. Invalid
+// cards, like the zero Card, return -1.
+func (c Card) index() int {
+	var suit = uint32(c.Suit())
+	if c.Rank() > Ace || bits.OnesCount32(suit) != 1 {
+		return -1
+	}
+	return int(c.Rank())*4 + bits.TrailingZeros32(suit)
+}
+
+// precedence orders cards by rank, then by suit when ranks match, from the
+// 2c at 0 to the As at 51. Suits rank spades, hearts, diamonds, clubs, as is
+// traditional when a card alone has to break a tie.
+func (c Card) precedence() int {
+	return int(c.Rank())*4 + 3 - bits.TrailingZeros32(uint32(c.Suit()))
+}
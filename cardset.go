@@ -0,0 +1,110 @@
+package poker
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// CardSet is a set of cards packed into the low 52 bits of an integer, one
+// bit per card, in the same order a new Deck is built: 2s, 2h, 2d, 2c, 3s, and
+// so on. Checking for a card, or combining sets, never needs a loop or an
+// allocation.
+//
+// A CardSet's zero value is an empty set.
+type CardSet uint64
+
+// cardsByIndex maps a CardSet bit position back to its card
+var cardsByIndex = func() (cards [52]Card) {
+	for i := range cards {
+		cards[i] = NewCard(CardRank(i/4), allSuits[i%4])
+	}
+	return cards
+}()
+
+// FullCardSet holds all 52 cards in a standard deck
+const FullCardSet CardSet = 1<<52 - 1
+
+// NewCardSet returns a set holding the given cards
+func NewCardSet(cards ...Card) CardSet {
+	var cs CardSet
+	for _, c := range cards {
+		cs.Add(c)
+	}
+	return cs
+}
+
+// Set returns a CardSet holding every card in the list
+func (cl CardList) Set() CardSet {
+	return NewCardSet(cl...)
+}
+
+// bit returns the card's bit in a set, or zero for an invalid card
+func (c Card) bit() CardSet {
+	var i = c.index()
+	if i < 0 {
+		return 0
+	}
+	return 1 << uint(i)
+}
+
+// Add puts the card into the set. Invalid cards, like the zero Card, are
+// ignored.
+func (cs *CardSet) Add(c Card) {
+	*cs |= c.bit()
+}
+
+// Remove takes the card out of the set
+func (cs *CardSet) Remove(c Card) {
+	*cs &^= c.bit()
+}
+
+// Contains returns true if the card is in the set. It's always false for an
+// invalid card.
+func (cs CardSet) Contains(c Card) bool {
+	return cs&c.bit() != 0
+}
+
+// Union returns a set with every card in either set
+func (cs CardSet) Union(other CardSet) CardSet {
+	return cs | other
+}
+
+// Intersect returns a set with only the cards in both sets
+func (cs CardSet) Intersect(other CardSet) CardSet {
+	return cs & other
+}
+
+// Without returns a set with every card in this set that isn't in the other
+func (cs CardSet) Without(other CardSet) CardSet {
+	return cs &^ other
+}
+
+// Count returns the number of cards in the set
+func (cs CardSet) Count() int {
+	return bits.OnesCount64(uint64(cs))
+}
+
+// ForEach calls fn for every card in the set, in deck order
+func (cs CardSet) ForEach(fn func(Card)) {
+	for rest := uint64(cs & FullCardSet); rest != 0; rest &= rest - 1 {
+		fn(cardsByIndex[bits.TrailingZeros64(rest)])
+	}
+}
+
+// Cards returns the cards in the set as a list, in deck order
+func (cs CardSet) Cards() CardList {
+	var cl = make(CardList, 0, cs.Count())
+	cs.ForEach(func(c Card) {
+		cl = append(cl, c)
+	})
+	return cl
+}
+
+// String returns the cards in deck order, the same way CardList.String does
+func (cs CardSet) String() string {
+	var list = make([]string, 0, cs.Count())
+	cs.ForEach(func(c Card) {
+		list = append(list, c.String())
+	})
+	return strings.Join(list, " ")
+}
@@ -0,0 +1,107 @@
+package poker
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCardSet(t *testing.T) {
+	var cl, _ = ParseCards("Ah Kd 2s 7c")
+	var cs = cl.Set()
+
+	if cs.Count() != 4 {
+		t.Fatalf("Expected 4 cards in set, got %d", cs.Count())
+	}
+	for _, c := range cl {
+		if !cs.Contains(c) {
+			t.Errorf("Expected set to contain %s", c)
+		}
+	}
+	if cs.Contains(newCardString("As")) {
+		t.Errorf("Expected set not to contain As")
+	}
+
+	// Cards always come back in deck order
+	if cs.String() != "2s 7c Kd Ah" {
+		t.Errorf("Expected set to be %q, got %q", "2s 7c Kd Ah", cs.String())
+	}
+	if cs.Cards().String() != cs.String() {
+		t.Errorf("Expected Cards().String() to match String(), got %q and %q", cs.Cards(), cs)
+	}
+
+	cs.Remove(newCardString("Kd"))
+	cs.Remove(newCardString("Kd"))
+	cs.Add(newCardString("3h"))
+	cs.Add(newCardString("3h"))
+	if cs.String() != "2s 3h 7c Ah" {
+		t.Errorf("Expected set to be %q after changes, got %q", "2s 3h 7c Ah", cs.String())
+	}
+}
+
+func TestCardSetOperations(t *testing.T) {
+	var a = NewCardSet(newCardString("As"), newCardString("Kd"), newCardString("2c"))
+	var b = NewCardSet(newCardString("Kd"), newCardString("2c"), newCardString("9h"))
+
+	var tests = map[string]struct {
+		got      CardSet
+		expected string
+	}{
+		"Union":     {a.Union(b), "2c 9h Kd As"},
+		"Intersect": {a.Intersect(b), "2c Kd"},
+		"Without":   {a.Without(b), "As"},
+		"Empty":     {a.Intersect(0), ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tc.got.String() != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, tc.got.String())
+			}
+		})
+	}
+}
+
+func TestFullCardSet(t *testing.T) {
+	if FullCardSet.Count() != 52 {
+		t.Fatalf("Expected 52 cards in a full set, got %d", FullCardSet.Count())
+	}
+
+	// A full set iterates in the same order as a new deck
+	var deck = NewDeck(rand.NewSource(0))
+	var i int
+	FullCardSet.ForEach(func(c Card) {
+		if deck.cards[i] != c {
+			t.Fatalf("Card %d: expected %s, got %s", i, deck.cards[i], c)
+		}
+		i++
+	})
+	if deck.cards.Set() != FullCardSet {
+		t.Fatalf("Expected a new deck to be a full set")
+	}
+}
+
+func TestCardSetInvalidCards(t *testing.T) {
+	var tests = map[string]Card{
+		"Zero card":    Card(0),
+		"Two suits":    NewCard(Ten, Spades|Hearts),
+		"No such rank": Card(0xF<<8 | uint32(Spades)<<12),
+	}
+
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cs CardSet
+			cs.Add(c)
+			if cs != 0 {
+				t.Errorf("Expected adding an invalid card to do nothing, got %s", cs)
+			}
+			if FullCardSet.Contains(c) {
+				t.Errorf("Expected a full set not to contain an invalid card")
+			}
+			cs = FullCardSet
+			cs.Remove(c)
+			if cs != FullCardSet {
+				t.Errorf("Expected removing an invalid card to do nothing, got %d cards", cs.Count())
+			}
+		})
+	}
+}
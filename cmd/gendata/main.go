@@ -0,0 +1,421 @@
+// Command gendata rebuilds the five-card lookup tables in the poker package's
+// data.go from first principles: it enumerates all 7,462 distinct five-card
+// hands in order from best to worst, assigns each its score, and recreates the
+// perfect hash used to look up hands which have a repeated rank.
+//
+// Usage:
+//
+//	gendata [-o data.go] [-verify]
+//
+// With -verify, nothing is written. Instead the generated source is compared
+// byte-for-byte against the existing file, and any difference is an error.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+// Each rank gets a prime so that a product of five ranks is unique to that
+// combination of ranks regardless of order
+var primes = []uint32{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41}
+
+const (
+	deuce = 0
+	five  = 3
+	six   = 4
+	ace   = 12
+)
+
+// The rank-bit tables have to hold everything up to AKQJT
+const rankTableSize = 0x1f00 + 1
+
+// The perfect hash splits each mixed product into a 13-bit "a" and a 9-bit
+// "b" value, and the final hash is a ^ hashAdjust[b]
+const (
+	hashBits   = 13
+	bucketBits = 9
+)
+
+// Slots in hashValues which no hand can reach are filled with this value. It
+// has no meaning, but it's what the original tables used.
+const unusedHashValue = 166
+
+// The widest a line of the rank-bit tables can be (counting the leading tab
+// as one character) before the next value has to wrap
+const lineWidth = 66
+
+// tables holds everything we generate for data.go
+type tables struct {
+	flushes    []uint16
+	unique5    []uint16
+	hashAdjust []uint16
+	hashValues []uint16
+}
+
+func main() {
+	var out = flag.String("o", "data.go", "path to the generated file")
+	var verify = flag.Bool("verify", false, "compare generated source to the existing file instead of writing it")
+	flag.Parse()
+
+	var t, err = generate()
+	if err != nil {
+		log.Fatalf("Unable to generate tables: %s", err)
+	}
+	var src = t.source()
+
+	if !*verify {
+		err = ioutil.WriteFile(*out, src, 0644)
+		if err != nil {
+			log.Fatalf("Unable to write %q: %s", *out, err)
+		}
+		return
+	}
+
+	var existing []byte
+	existing, err = ioutil.ReadFile(*out)
+	if err != nil {
+		log.Fatalf("Unable to read %q: %s", *out, err)
+	}
+	if !bytes.Equal(existing, src) {
+		log.Fatalf("%q does not match the generated tables", *out)
+	}
+	log.Printf("%q matches the generated tables", *out)
+}
+
+// generate walks every equivalence class of five-card hands, best to worst,
+// scoring each in turn and storing the score wherever the evaluator will look
+// for it
+func generate() (*tables, error) {
+	var t = &tables{
+		flushes: make([]uint16, rankTableSize),
+		unique5: make([]uint16, rankTableSize),
+	}
+	var products = make(map[uint32]uint16)
+	var score uint16
+
+	var straights = straightMasks()
+	var isStraight = make(map[int]bool)
+	for _, m := range straights {
+		isStraight[m] = true
+	}
+
+	// Straight flushes
+	for _, m := range straights {
+		score++
+		t.flushes[m] = score
+	}
+
+	// Four of a kind, then full houses, by the big group and then the small
+	for _, sizes := range [][2]int{{4, 1}, {3, 2}} {
+		for big := ace; big >= deuce; big-- {
+			for small := ace; small >= deuce; small-- {
+				if small == big {
+					continue
+				}
+				score++
+				products[rankProduct(big, sizes[0])*rankProduct(small, sizes[1])] = score
+			}
+		}
+	}
+
+	// Flushes: any five unique ranks that aren't a straight. Walking the masks
+	// downward compares the highest card first, then the next, and so on.
+	var masks = uniqueMasks()
+	for _, m := range masks {
+		if !isStraight[m] {
+			score++
+			t.flushes[m] = score
+		}
+	}
+
+	// Straights
+	for _, m := range straights {
+		score++
+		t.unique5[m] = score
+	}
+
+	// Three of a kind
+	for trips := ace; trips >= deuce; trips-- {
+		for _, kickers := range kickerSets(2, trips) {
+			score++
+			products[rankProduct(trips, 3)*kickers] = score
+		}
+	}
+
+	// Two pair
+	for high := ace; high >= deuce; high-- {
+		for low := high - 1; low >= deuce; low-- {
+			for _, kicker := range kickerSets(1, high, low) {
+				score++
+				products[rankProduct(high, 2)*rankProduct(low, 2)*kicker] = score
+			}
+		}
+	}
+
+	// One pair
+	for pair := ace; pair >= deuce; pair-- {
+		for _, kickers := range kickerSets(3, pair) {
+			score++
+			products[rankProduct(pair, 2)*kickers] = score
+		}
+	}
+
+	// High card
+	for _, m := range masks {
+		if !isStraight[m] {
+			score++
+			t.unique5[m] = score
+		}
+	}
+
+	if score != 7462 {
+		return nil, fmt.Errorf("found %d distinct hands; expected 7462", score)
+	}
+
+	var err = t.buildHash(products)
+	return t, err
+}
+
+// straightMasks returns the rank bits of all ten straights, highest first
+func straightMasks() []int {
+	var masks []int
+	for high := ace; high > five; high-- {
+		masks = append(masks, 0x1f<<uint(high-4))
+	}
+
+	// The wheel: A-2-3-4-5
+	return append(masks, 1<<ace|0xf)
+}
+
+// uniqueMasks returns every set of five distinct ranks as rank bits, in
+// descending order
+func uniqueMasks() []int {
+	var masks []int
+	for m := rankTableSize - 1; m > 0; m-- {
+		if bitCount(m) == 5 {
+			masks = append(masks, m)
+		}
+	}
+	return masks
+}
+
+func bitCount(n int) int {
+	var count int
+	for ; n != 0; n &= n - 1 {
+		count++
+	}
+	return count
+}
+
+// rankProduct returns the prime product for n cards of the given rank
+func rankProduct(rank, n int) uint32 {
+	var p uint32 = 1
+	for i := 0; i < n; i++ {
+		p *= primes[rank]
+	}
+	return p
+}
+
+// kickerSets returns the prime products of every set of n distinct kickers
+// which don't use any of the excluded ranks, best kickers first
+func kickerSets(n int, exclude ...int) []uint32 {
+	var sets []uint32
+	var build func(from, left int, product uint32)
+	build = func(from, left int, product uint32) {
+		if left == 0 {
+			sets = append(sets, product)
+			return
+		}
+		for r := from; r >= deuce; r-- {
+			if !contains(exclude, r) {
+				build(r-1, left-1, product*primes[r])
+			}
+		}
+	}
+	build(ace, n, 1)
+	return sets
+}
+
+func contains(list []int, n int) bool {
+	for _, v := range list {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+// mix scrambles a prime product the same way the evaluator's findFast does,
+// returning the a and b halves of the hash
+func mix(product uint32) (a, b uint32) {
+	product += 0xe91aaa35
+	product ^= product >> 16
+	product += product << 8
+	product ^= product >> 4
+	b = (product >> 8) & (1<<bucketBits - 1)
+	a = (product + (product << 2)) >> 19
+	return a, b
+}
+
+// buildHash creates the hashAdjust and hashValues tables so that every prime
+// product of a hand with repeated ranks finds its score in hashValues at
+// mix(product).a ^ hashAdjust[mix(product).b].
+//
+// This follows Bob Jenkins' perfect hash generator: the "b" buckets with the
+// most keys are placed first (ties broken by lowest b), and each bucket gets
+// the first value from a scrambled sequence which puts all its keys into
+// empty slots.
+func (t *tables) buildHash(products map[uint32]uint16) error {
+	var buckets = make([][]uint32, 1<<bucketBits)
+	var scores = make(map[uint32]uint16)
+	var biggest int
+	for product, score := range products {
+		var a, b = mix(product)
+		buckets[b] = append(buckets[b], a)
+		if len(buckets[b]) > biggest {
+			biggest = len(buckets[b])
+		}
+		if _, ok := scores[a<<bucketBits|b]; ok {
+			return fmt.Errorf("two hands mix to the same a/b values")
+		}
+		scores[a<<bucketBits|b] = score
+	}
+
+	var scramble = make([]uint16, 1<<hashBits)
+	for i := range scramble {
+		scramble[i] = permute(uint32(i), hashBits)
+	}
+
+	t.hashAdjust = make([]uint16, 1<<bucketBits)
+	t.hashValues = make([]uint16, 1<<hashBits)
+	var used = make([]bool, 1<<hashBits)
+	for size := biggest; size > 0; size-- {
+		for b, keys := range buckets {
+			if len(keys) != size {
+				continue
+			}
+			var adjust, ok = placeBucket(keys, scramble, used)
+			if !ok {
+				return fmt.Errorf("unable to place bucket %d", b)
+			}
+			t.hashAdjust[b] = adjust
+			for _, a := range keys {
+				used[a^uint32(adjust)] = true
+				t.hashValues[a^uint32(adjust)] = scores[a<<bucketBits|uint32(b)]
+			}
+		}
+	}
+
+	for i, u := range used {
+		if !u {
+			t.hashValues[i] = unusedHashValue
+		}
+	}
+	return nil
+}
+
+// placeBucket finds the first scramble value which puts all the bucket's keys
+// into unused, distinct slots
+func placeBucket(keys []uint32, scramble []uint16, used []bool) (uint16, bool) {
+	for _, adjust := range scramble {
+		var ok = true
+		for i, a := range keys {
+			var slot = a ^ uint32(adjust)
+			if used[slot] {
+				ok = false
+				break
+			}
+			for _, other := range keys[:i] {
+				if other^uint32(adjust) == slot {
+					ok = false
+					break
+				}
+			}
+		}
+		if ok {
+			return adjust, true
+		}
+	}
+
+	return 0, false
+}
+
+// permute is the deterministic shuffle from Jenkins' perfect hash generator,
+// mixing x within the range of nbits
+func permute(x, nbits uint32) uint16 {
+	var mask = uint32(1)<<nbits - 1
+	var c2, c3, c4, c5 = 1 + nbits/2, 1 + nbits/3, 1 + nbits/4, 1 + nbits/5
+	for i := 0; i < 20; i++ {
+		x = (x + (x << c2)) & mask
+		x = x ^ (x >> c3)
+		x = (x + (x << c4)) & mask
+		x = x ^ (x >> c5)
+	}
+	return uint16(x)
+}
+
+// source returns the full Go source for data.go
+func (t *tables) source() []byte {
+	var b bytes.Buffer
+	b.WriteString("// data.go holds all our various constant pieces of data we need for lookups to\n")
+	b.WriteString("// work quickly\n\n")
+	b.WriteString("package poker\n\n")
+
+	var p []string
+	for _, n := range primes {
+		p = append(p, fmt.Sprint(n))
+	}
+	fmt.Fprintf(&b, "var primes = []uint32{%s}\n\n", strings.Join(p, ", "))
+
+	b.WriteString("// All possible flush hands' representations\n")
+	writeWrapped(&b, "flushes", t.flushes)
+	b.WriteString("\n// All non-flush five-card hands where all five cards have a unique rank\n")
+	writeWrapped(&b, "unique5", t.unique5)
+	b.WriteString("\n")
+	writeColumns(&b, "hashAdjust", t.hashAdjust)
+	b.WriteString("\n")
+	writeColumns(&b, "hashValues", t.hashValues)
+
+	return b.Bytes()
+}
+
+// writeWrapped writes a uint16 slice, fitting as many values on each line as
+// lineWidth allows
+func writeWrapped(b *bytes.Buffer, name string, values []uint16) {
+	fmt.Fprintf(b, "var %s = []uint16{\n", name)
+	var line = "\t"
+	for _, v := range values {
+		var s = fmt.Sprintf("%d,", v)
+		if line != "\t" && len(line)+1+len(s) > lineWidth {
+			b.WriteString(line + "\n")
+			line = "\t"
+		}
+		if line != "\t" {
+			line += " "
+		}
+		line += s
+	}
+	b.WriteString(line + "\n}\n")
+}
+
+// writeColumns writes a uint16 slice with sixteen values per line
+func writeColumns(b *bytes.Buffer, name string, values []uint16) {
+	fmt.Fprintf(b, "var %s = []uint16{\n", name)
+	for i := 0; i < len(values); i += 16 {
+		var end = i + 16
+		if end > len(values) {
+			end = len(values)
+		}
+		var s []string
+		for _, v := range values[i:end] {
+			s = append(s, fmt.Sprintf("%d,", v))
+		}
+		b.WriteString("\t" + strings.Join(s, " ") + "\n")
+	}
+	b.WriteString("}\n")
+}
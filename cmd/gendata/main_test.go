@@ -0,0 +1,23 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestGenerateMatchesData(t *testing.T) {
+	var tables, err = generate()
+	if err != nil {
+		t.Fatalf("Unable to generate tables: %s", err)
+	}
+
+	var existing []byte
+	existing, err = ioutil.ReadFile("../../data.go")
+	if err != nil {
+		t.Fatalf("Unable to read data.go: %s", err)
+	}
+	if !bytes.Equal(existing, tables.source()) {
+		t.Fatalf("Generated source doesn't match data.go")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Nerdmaster/poker"
@@ -43,11 +44,31 @@ func main() {
 		p.result = res
 	}
 
-	sort.Slice(players, func(i, j int) bool {
-		return players[i].result.Score < players[j].result.Score
+	sort.SliceStable(players, func(i, j int) bool {
+		return poker.Compare(players[i].result, players[j].result) > 0
 	})
 
 	for i, p := range players {
-		log.Printf("In position %d, we have %s (%q) with %s (%s)", i+1, p.name, p.hand, p.result.Best5, p.result.Describe())
+		var sh, err = poker.NewStartingHand(p.result.Hand)
+		if err != nil {
+			log.Fatalf("Error classifying hand %s: %s", p.hand, err)
+		}
+		log.Printf("In position %d, we have %s, who had %s (%q), with %s (%s)", i+1, p.name, sh, p.hand, p.result.Best5, p.result.Describe())
+	}
+
+	var results = make([]*poker.HandResult, len(players))
+	for i, p := range players {
+		results[i] = p.result
+	}
+	var winners = poker.Winners(results...)
+	if len(winners) == 1 {
+		log.Printf("%s wins", players[winners[0]].name)
+		return
+	}
+
+	var names []string
+	for _, i := range winners {
+		names = append(names, players[i].name)
 	}
+	log.Printf("Split pot between %s", strings.Join(names, ", "))
 }
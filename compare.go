@@ -0,0 +1,73 @@
+package poker
+
+// Compare returns a positive number if a is the better hand, a negative number
+// if b is better, or zero if they tie. Both results must have been scored
+// with the same Scoring rules. A nil result loses to any hand, which makes it
+// handy for players who've folded or don't qualify.
+func Compare(a, b *HandResult) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	case a.Score < b.Score:
+		return 1
+	case a.Score > b.Score:
+		return -1
+	}
+	return 0
+}
+
+// CompareLow is Compare for the low halves of hi-lo results: a hand with a
+// qualifying low beats one without, and two hands without a low tie
+func CompareLow(a, b *HandResult) int {
+	return Compare(lowOf(a), lowOf(b))
+}
+
+func lowOf(hr *HandResult) *HandResult {
+	if hr == nil {
+		return nil
+	}
+	return hr.Low
+}
+
+// Winners returns the indices of the best hands in results: one index if a
+// single hand wins outright, or more if the best hands tie. Nil results are
+// skipped, so folded players can keep their seat in the list. If every
+// result is nil, there are no winners.
+func Winners(results ...*HandResult) []int {
+	var winners []int
+	var best *HandResult
+	for i, hr := range results {
+		if hr == nil {
+			continue
+		}
+
+		var cmp = Compare(hr, best)
+		if cmp > 0 {
+			winners = winners[:0]
+			best = hr
+		}
+		if cmp >= 0 {
+			winners = append(winners, i)
+		}
+	}
+	return winners
+}
+
+// HiLoWinners returns the indices of the winners of each half of a split pot,
+// using the results from Hand.EvaluateHiLo. If nobody has a qualifying low,
+// lows is empty and the high hands scoop the whole pot.
+func HiLoWinners(results ...*HandResult) (highs, lows []int) {
+	highs = Winners(results...)
+
+	var lowResults = make([]*HandResult, len(results))
+	for i, hr := range results {
+		lowResults[i] = lowOf(hr)
+	}
+	lows = Winners(lowResults...)
+
+	return highs, lows
+}
@@ -0,0 +1,116 @@
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+// mustEvaluate returns the results for each hand with the given community
+// cards, or nil for an empty hand string
+func mustEvaluate(t *testing.T, hiLo bool, community string, hands ...string) []*HandResult {
+	t.Helper()
+	var comm, err = ParseCards(community)
+	if err != nil {
+		t.Fatalf("Unable to parse community %q: %s", community, err)
+	}
+
+	var results = make([]*HandResult, len(hands))
+	for i, s := range hands {
+		if s == "" {
+			continue
+		}
+		var h, err = makeHand(s)
+		if err != nil {
+			t.Fatalf("Unable to parse hand %q: %s", s, err)
+		}
+		if hiLo {
+			results[i], err = h.EvaluateHiLo(comm...)
+		} else {
+			results[i], err = h.Evaluate(comm...)
+		}
+		if err != nil {
+			t.Fatalf("Unable to evaluate %q: %s", s, err)
+		}
+	}
+	return results
+}
+
+func TestCompare(t *testing.T) {
+	var r = mustEvaluate(t, false, "Ah Kd 7c 7s 2h", "As Qd", "Ac Jc", "Kh Ks")
+	var tests = map[string]struct {
+		a, b     *HandResult
+		expected int
+	}{
+		"Better":      {r[2], r[0], 1},
+		"Worse":       {r[0], r[2], -1},
+		"Tie":         {r[0], r[1], 0},
+		"Nil loses":   {nil, r[0], -1},
+		"Beats nil":   {r[0], nil, 1},
+		"Nil and nil": {nil, nil, 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got = Compare(tc.a, tc.b)
+			if got != tc.expected {
+				t.Errorf("Expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestWinners(t *testing.T) {
+	var tests = map[string]struct {
+		hands    []string
+		expected []int
+	}{
+		"Outright":      {[]string{"As Qd", "Kh Ks"}, []int{1}},
+		"Tie":           {[]string{"As Qd", "Ac Jc"}, []int{0, 1}},
+		"Three-way":     {[]string{"3c 4c", "As Qd", "Kh Ks", "Ac Jc"}, []int{2}},
+		"Split":         {[]string{"3c 4c", "As Qd", "5d 6d", "Ac Jc"}, []int{1, 3}},
+		"Folded seats":  {[]string{"", "As Qd", "", "Ac Jc"}, []int{1, 3}},
+		"Everybody out": {[]string{"", ""}, nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var results = mustEvaluate(t, false, "Ah Kd 7c 7s 2h", tc.hands...)
+			var got = Winners(results...)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected winners %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestHiLoWinners(t *testing.T) {
+	var tests = map[string]struct {
+		community string
+		hands     []string
+		highs     []int
+		lows      []int
+	}{
+		"Split, tied low":   {"2c 5d 8h Kc Ks", []string{"As 3h 4d Qd", "Kh Qh 9s 9c", "Ah 3c Jd Jc"}, []int{1}, []int{0, 2}},
+		"Scoop":             {"Kc Qd Jh 9s 9c", []string{"As 3h 4d Qh", "Ah Th 2c 3c"}, []int{1}, nil},
+		"Low wins both":     {"2c 4h 5s Kc Qs", []string{"As 3h 4d 6d", "Ah Jc Td 9d"}, []int{0}, []int{0}},
+		"Folded low player": {"2c 5d 8h Kc Ks", []string{"", "Kh Qh 9s 9c", "Ah 3c Jd Jc"}, []int{1}, []int{2}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var results = mustEvaluate(t, true, tc.community, tc.hands...)
+			var highs, lows = HiLoWinners(results...)
+			if !reflect.DeepEqual(highs, tc.highs) {
+				t.Errorf("Expected high winners %v, got %v", tc.highs, highs)
+			}
+			if !reflect.DeepEqual(lows, tc.lows) {
+				t.Errorf("Expected low winners %v, got %v", tc.lows, lows)
+			}
+		})
+	}
+
+	var r = mustEvaluate(t, true, "2c 5d 8h Kc Ks", "As 3h 4d Qd", "Kh Qh 9s 9c")
+	if CompareLow(r[0], r[1]) != 1 || CompareLow(r[1], r[0]) != -1 || CompareLow(r[1], nil) != 0 {
+		t.Errorf("Expected a qualifying low to beat no low, and no low to tie no low")
+	}
+}
@@ -12,9 +12,15 @@ type CardReceiver interface {
 // A Deck is a magical list of cards that happens to be able to initialize
 // itself to a standard 52-card setup as well as be shuffled and have cards
 // drawn, removing them from the deck.
+//
+// Cards can also be mucked back to the dealer, as in draw games. Mucked cards
+// sit to the side until the deck runs out, then get shuffled and put under
+// whatever's left.
 type Deck struct {
-	rnd   *rand.Rand
-	cards CardList
+	rnd    *rand.Rand
+	cards  CardList
+	muck   CardList
+	lowest CardRank
 }
 
 // NewDeck returns a deck of 52 cards.  These are not shuffled in any way.
@@ -31,6 +37,15 @@ func NewDeck(rndSource rand.Source) *Deck {
 	return deck
 }
 
+// NewShortDeck returns a 36-card deck for short-deck (6+) Hold 'em: sixes
+// through aces only. Otherwise it behaves exactly like a deck from NewDeck,
+// including when it's Reset.
+func NewShortDeck(rndSource rand.Source) *Deck {
+	var deck = &Deck{rnd: rand.New(rndSource), lowest: Six}
+	deck.Reset()
+	return deck
+}
+
 // Shuffle does what you think - randomizes the cards in the deck.  To
 // re-initialize the deck with a full set of cards, use Reset().
 func (d *Deck) Shuffle() {
@@ -39,48 +54,77 @@ func (d *Deck) Shuffle() {
 	})
 }
 
-// Reset puts all cards back into the deck in their original order
+// Reset puts all cards back into the deck in their original order, including
+// any that were mucked
 func (d *Deck) Reset() {
-	d.cards = make(CardList, 52)
-	var idx = 0
-	for rank := Deuce; rank <= Ace; rank++ {
-		for _, suit := range []CardSuit{Spades, Hearts, Diamonds, Clubs} {
-			d.cards[idx] = NewCard(rank, suit)
-			idx++
+	d.cards = make(CardList, 4*int(Ace-d.lowest+1))
+	copy(d.cards, cardsByIndex[4*int(d.lowest):])
+	d.muck = nil
+}
+
+// Muck gives discarded cards back to the dealer. They aren't drawn again
+// until the rest of the deck runs out.
+func (d *Deck) Muck(cards ...Card) {
+	d.muck = append(d.muck, cards...)
+}
+
+// reshuffleMuck shuffles the mucked cards and puts them under the deck
+func (d *Deck) reshuffleMuck() {
+	d.rnd.Shuffle(len(d.muck), func(i, j int) {
+		d.muck[i], d.muck[j] = d.muck[j], d.muck[i]
+	})
+	d.cards = append(d.cards, d.muck...)
+	d.muck = nil
+}
+
+// Remove takes the given cards out of the deck if they're in it, such as
+// cards already known to be in players' hands. The remaining cards stay in
+// the same order.
+func (d *Deck) Remove(cards CardSet) {
+	var kept = d.cards[:0]
+	for _, c := range d.cards {
+		if !cards.Contains(c) {
+			kept = append(kept, c)
 		}
 	}
+	d.cards = kept
 }
 
-// Draw returns up to n cards.  If n is larger than the number of cards left in
-// the deck, only that many cards are returned.  A zero-length slice can be
+// Draw returns up to n cards.  If there aren't enough left in the deck, any
+// mucked cards are shuffled in first.  If n is still larger than the number of
+// cards left, only that many cards are returned.  A zero-length slice can be
 // returned if the deck is empty.
 func (d *Deck) Draw(n int) (cards CardList) {
+	if len(d.cards) < n && len(d.muck) > 0 {
+		d.reshuffleMuck()
+	}
 	if len(d.cards) < n {
 		n = len(d.cards)
 	}
 
-	cards, d.cards = d.cards[:n], d.cards[n:]
+	// Cap the returned slice so appending to it can't overwrite the deck
+	cards, d.cards = d.cards[:n:n], d.cards[n:]
 	return cards
 }
 
 // Deal adds a card to the given card receiver, removing it from the deck. An error is
-// returned if there are no cards available.
+// returned if there are no cards available, even in the muck.
 func (d *Deck) Deal(i CardReceiver) error {
-	if d.Empty() {
+	var cards = d.Draw(1)
+	if len(cards) == 0 {
 		return ErrEmptyDeck
 	}
-	var cards = d.Draw(1)
 	i.AddCard(cards[0])
 
 	return nil
 }
 
-// Count returns the number of cards left in the deck
+// Count returns the number of cards left in the deck, not counting the muck
 func (d *Deck) Count() int {
 	return len(d.cards)
 }
 
-// Empty returns true if the deck has no more cards
+// Empty returns true if the deck has no more cards, not counting the muck
 func (d *Deck) Empty() bool {
 	return len(d.cards) == 0
 }
@@ -1,6 +1,7 @@
 package poker
 
 import (
+	"errors"
 	"math/rand"
 	"testing"
 )
@@ -51,3 +52,82 @@ func TestEmpty(t *testing.T) {
 		t.Fatalf("Deck with 52 cards drawn wasn't reporting being empty")
 	}
 }
+
+func TestNewShortDeck(t *testing.T) {
+	var deck = NewShortDeck(rand.NewSource(0))
+	if deck.Count() != 36 {
+		t.Fatalf("Expected a short deck to have 36 cards, but it has %d", deck.Count())
+	}
+
+	deck.Shuffle()
+	deck.Draw(10)
+	deck.Reset()
+	if deck.Count() != 36 {
+		t.Fatalf("Expected a reset short deck to have 36 cards, but it has %d", deck.Count())
+	}
+	for _, c := range deck.cards {
+		if c.Rank() < Six {
+			t.Fatalf("Short deck contains %s", c)
+		}
+	}
+}
+
+func TestRemove(t *testing.T) {
+	var deck = NewDeck(rand.NewSource(0))
+	var known, _ = ParseCards("As Kd 2s")
+	deck.Remove(known.Set())
+
+	if deck.Count() != 49 {
+		t.Fatalf("Expected 49 cards after removing 3, got %d", deck.Count())
+	}
+	if deck.cards.Set().Intersect(known.Set()) != 0 {
+		t.Fatalf("Expected removed cards to be gone, but the deck still has %s", deck.cards.Set().Intersect(known.Set()))
+	}
+	if deck.cards[0].String() != "2h" {
+		t.Fatalf("Expected the deck to stay in order, but the first card is %s", deck.cards[0])
+	}
+}
+
+func TestMuck(t *testing.T) {
+	var deck = NewDeck(rand.NewSource(0))
+	var mucked = deck.Draw(5)
+	deck.Draw(45)
+	deck.Muck(mucked...)
+
+	// Two cards are left, then the muck is shuffled in under them
+	var next = deck.cards
+	var cards = deck.Draw(4)
+	if len(cards) != 4 || cards[0] != next[0] || cards[1] != next[1] {
+		t.Fatalf("Expected the last two cards before the muck, got %s", cards)
+	}
+	for _, c := range cards[2:] {
+		if !mucked.Set().Contains(c) {
+			t.Fatalf("Expected %s to come from the muck %s", c, mucked)
+		}
+	}
+	if deck.Count() != 3 || len(deck.muck) != 0 {
+		t.Fatalf("Expected three mucked cards left in the deck, got %d", deck.Count())
+	}
+
+	deck.Draw(3)
+	var err = deck.Deal(NewHand(nil))
+	if !errors.Is(err, ErrEmptyDeck) {
+		t.Fatalf("Expected an empty deck and muck to be %q, got %v", ErrEmptyDeck, err)
+	}
+
+	deck.Muck(mucked...)
+	deck.Reset()
+	if deck.Count() != 52 || len(deck.muck) != 0 {
+		t.Fatalf("Expected Reset to empty the muck, got %d cards and %d mucked", deck.Count(), len(deck.muck))
+	}
+}
+
+func TestDrawDoesNotAlias(t *testing.T) {
+	var deck = NewDeck(rand.NewSource(0))
+	var cards = deck.Draw(5)
+	var next = deck.cards[0]
+	cards = append(cards, cards[0])
+	if deck.cards[0] != next {
+		t.Fatalf("Expected appending to drawn cards to leave the deck alone, but %s became %s", next, deck.cards[0])
+	}
+}
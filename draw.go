@@ -0,0 +1,204 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// drawHandSize is how many cards each player holds in a draw game
+const drawHandSize = 5
+
+// maxDrawPlayers is as many players as a draw game can seat. Discards are
+// reshuffled when the deck runs out, so the only limit is dealing everybody
+// their first five cards with some left over.
+const maxDrawPlayers = 8
+
+// DrawGame deals draw poker: five cards down to each player, then one or
+// more draw rounds where each player in turn discards some cards and is dealt
+// replacements. Five-card draw has one draw round and is scored high; 2-7
+// triple draw has three rounds and is scored with DeuceToSevenLow. Like Stud,
+// it leaves the betting between rounds to the caller.
+//
+// Players are numbered from the dealer's left, and draw in that order. When
+// the deck runs out, the discards are shuffled and dealt from.
+type DrawGame struct {
+	deck    *Deck
+	scoring Scoring
+	rounds  int
+
+	hands  []*Hand
+	folded []bool
+	round  int
+	toDraw int
+}
+
+// NewDrawGame returns a draw game for two to eight players with the given
+// number of draw rounds, scored with the given rules. ShortDeckHigh games are
+// dealt from a short deck, which only seats seven.
+func NewDrawGame(numPlayers, drawRounds int, s Scoring, rndSource rand.Source) (*DrawGame, error) {
+	if s.String() == "" {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownScoring, s)
+	}
+	if drawRounds < 1 {
+		return nil, fmt.Errorf("%w: a draw game needs at least one draw round", ErrInvalidCardCount)
+	}
+
+	var deck = NewDeck(rndSource)
+	if s == ShortDeckHigh {
+		deck = NewShortDeck(rndSource)
+	}
+	if numPlayers < 2 || numPlayers > maxDrawPlayers || numPlayers*drawHandSize >= deck.Count() {
+		return nil, fmt.Errorf("%w: %d players can't play from a %d-card deck", ErrInvalidPlayerCount, numPlayers, deck.Count())
+	}
+
+	var g = &DrawGame{
+		deck:    deck,
+		scoring: s,
+		rounds:  drawRounds,
+		hands:   make([]*Hand, numPlayers),
+		folded:  make([]bool, numPlayers),
+	}
+	g.Reset()
+	return g, nil
+}
+
+// Reset gathers up the cards, shuffles them, and deals a new hand
+func (g *DrawGame) Reset() {
+	g.deck.Reset()
+	g.deck.Shuffle()
+	for i := range g.hands {
+		g.hands[i] = NewHand(nil)
+		g.folded[i] = false
+	}
+	for round := 0; round < drawHandSize; round++ {
+		for _, h := range g.hands {
+			g.deck.Deal(h)
+		}
+	}
+	g.round = 1
+	g.toDraw = 0
+}
+
+// NumPlayers returns how many players are in the game
+func (g *DrawGame) NumPlayers() int {
+	return len(g.hands)
+}
+
+// Hand returns a copy of the given player's cards
+func (g *DrawGame) Hand(i int) CardList {
+	return append(CardList(nil), g.hands[i].cards...)
+}
+
+// Folded returns true if the given player has folded
+func (g *DrawGame) Folded(i int) bool {
+	return g.folded[i]
+}
+
+// Round returns the draw round in progress, starting with 1. Once every
+// round is over, it's one more than the number of draw rounds.
+func (g *DrawGame) Round() int {
+	return g.round
+}
+
+// ToDraw returns the player whose turn it is to draw, or -1 if the draws are
+// over
+func (g *DrawGame) ToDraw() int {
+	if g.done() {
+		return -1
+	}
+	return g.toDraw
+}
+
+// done returns true once all draw rounds are over, or everybody but one
+// player has folded
+func (g *DrawGame) done() bool {
+	return g.round > g.rounds || g.active() < 2
+}
+
+// active returns how many players haven't folded
+func (g *DrawGame) active() int {
+	var n int
+	for _, folded := range g.folded {
+		if !folded {
+			n++
+		}
+	}
+	return n
+}
+
+// Draw discards the given cards from the hand of the player whose turn it is
+// and deals them replacements. With no cards, the player stands pat. Once
+// every player still in has drawn, the next round starts.
+func (g *DrawGame) Draw(discards ...Card) error {
+	if g.done() {
+		return fmt.Errorf("%w: the draws are over", ErrIllegalAction)
+	}
+
+	var err = g.hands[g.toDraw].Replace(g.deck, discards...)
+	if err != nil {
+		return err
+	}
+	g.advance()
+	return nil
+}
+
+// Fold takes a player out of the hand and mucks their cards. A player can
+// fold at any time, since betting happens outside the game, but the last
+// player left can't.
+func (g *DrawGame) Fold(i int) error {
+	if i < 0 || i >= len(g.hands) {
+		return fmt.Errorf("%w: %d", ErrInvalidSeat, i)
+	}
+	if g.folded[i] || g.active() < 2 {
+		return fmt.Errorf("%w: player %d can't fold", ErrIllegalAction, i)
+	}
+
+	g.folded[i] = true
+	g.deck.Muck(g.hands[i].cards...)
+	if !g.done() && i == g.toDraw {
+		g.toDraw--
+		g.advance()
+	}
+	return nil
+}
+
+// advance moves the draw to the next player still in, starting a new round
+// after the last one
+func (g *DrawGame) advance() {
+	for {
+		g.toDraw++
+		if g.toDraw == len(g.hands) {
+			g.toDraw = 0
+			g.round++
+		}
+		if !g.folded[g.toDraw] {
+			return
+		}
+	}
+}
+
+// Results scores every hand still in once the draws are over. Results are
+// indexed by player, with nil for players who folded. If everybody else
+// folded, the last player's result is nil, too, since they don't have to
+// show.
+func (g *DrawGame) Results() ([]*HandResult, error) {
+	var results = make([]*HandResult, len(g.hands))
+	if g.active() == 1 {
+		return results, nil
+	}
+	if !g.done() {
+		return nil, fmt.Errorf("%w: the draws aren't over", ErrIllegalAction)
+	}
+
+	for i, h := range g.hands {
+		if g.folded[i] {
+			continue
+		}
+		var hr, err = h.EvaluateAs(g.scoring)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = hr
+	}
+	return results, nil
+}
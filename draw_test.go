@@ -0,0 +1,144 @@
+package poker
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestDrawGameTripleDraw(t *testing.T) {
+	var g, err = NewDrawGame(6, 3, DeuceToSevenLow, rand.NewSource(1))
+	if err != nil {
+		t.Fatalf("Unable to create game: %s", err)
+	}
+
+	// Everybody draws five every round: 30 cards dealt and 90 drawn is far
+	// more than one deck, so the muck has to be reshuffled along the way
+	for round := 1; round <= 3; round++ {
+		if g.Round() != round {
+			t.Fatalf("Expected round %d, got %d", round, g.Round())
+		}
+		for i := 0; i < 6; i++ {
+			if g.ToDraw() != i {
+				t.Fatalf("Expected player %d to draw, got %d", i, g.ToDraw())
+			}
+			var err = g.Draw(g.Hand(i)...)
+			if err != nil {
+				t.Fatalf("Round %d: player %d unable to draw: %s", round, i, err)
+			}
+		}
+	}
+
+	if g.ToDraw() != -1 {
+		t.Fatalf("Expected the draws to be over, got player %d to draw", g.ToDraw())
+	}
+	err = g.Draw()
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected a fourth draw to be %q, got %v", ErrIllegalAction, err)
+	}
+
+	var seen CardSet
+	for i := 0; i < 6; i++ {
+		for _, c := range g.Hand(i) {
+			if seen.Contains(c) {
+				t.Fatalf("%s is in two hands", c)
+			}
+			seen.Add(c)
+		}
+	}
+
+	var results []*HandResult
+	results, err = g.Results()
+	if err != nil {
+		t.Fatalf("Unable to get results: %s", err)
+	}
+	for i, hr := range results {
+		if hr == nil || hr.Scoring != DeuceToSevenLow {
+			t.Errorf("Expected player %d to have a 2-7 result, got %v", i, hr)
+		}
+	}
+}
+
+func TestDrawGameFold(t *testing.T) {
+	var g, _ = NewDrawGame(3, 1, HighScoring, rand.NewSource(1))
+	var _, err = g.Results()
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected results before the draw to be %q, got %v", ErrIllegalAction, err)
+	}
+
+	// Player 0 stands pat, and player 1 folds when it's their turn
+	var pat = g.Hand(0)
+	err = g.Draw()
+	if err != nil {
+		t.Fatalf("Unable to stand pat: %s", err)
+	}
+	if g.Hand(0).String() != pat.String() {
+		t.Errorf("Expected standing pat to keep %s, got %s", pat, g.Hand(0))
+	}
+	err = g.Fold(1)
+	if err != nil {
+		t.Fatalf("Unable to fold: %s", err)
+	}
+	if g.ToDraw() != 2 || !g.Folded(1) {
+		t.Fatalf("Expected player 2 to draw after player 1 folded, got %d", g.ToDraw())
+	}
+
+	var bad = g.Hand(0)[:1]
+	err = g.Draw(bad...)
+	if !errors.Is(err, ErrCardNotInHand) {
+		t.Errorf("Expected discarding another player's card to be %q, got %v", ErrCardNotInHand, err)
+	}
+
+	err = g.Draw(g.Hand(2)[:3]...)
+	if err != nil {
+		t.Fatalf("Unable to draw: %s", err)
+	}
+
+	var results []*HandResult
+	results, err = g.Results()
+	if err != nil || results[0] == nil || results[1] != nil || results[2] == nil {
+		t.Errorf("Expected results for players 0 and 2 only, got %v (%v)", results, err)
+	}
+
+	g.Reset()
+	if g.Fold(0) != nil || g.Fold(1) != nil {
+		t.Fatalf("Unable to fold")
+	}
+	err = g.Fold(2)
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected the last player folding to be %q, got %v", ErrIllegalAction, err)
+	}
+	if g.ToDraw() != -1 {
+		t.Errorf("Expected no draw with one player left, got player %d", g.ToDraw())
+	}
+	results, err = g.Results()
+	if err != nil || results[2] != nil {
+		t.Errorf("Expected nobody to show down, got %v (%v)", results, err)
+	}
+}
+
+func TestNewDrawGameErrors(t *testing.T) {
+	var tests = map[string]struct {
+		players, rounds int
+		scoring         Scoring
+		err             error
+	}{
+		"One player":         {1, 1, HighScoring, ErrInvalidPlayerCount},
+		"Nine players":       {9, 1, HighScoring, ErrInvalidPlayerCount},
+		"Full short deck":    {8, 1, ShortDeckHigh, ErrInvalidPlayerCount},
+		"No draws":           {6, 0, DeuceToSevenLow, ErrInvalidCardCount},
+		"Unknown scoring":    {6, 1, Scoring(99), ErrUnknownScoring},
+		"Short-deck draw":    {7, 1, ShortDeckHigh, nil},
+		"Eight-handed draw":  {8, 1, HighScoring, nil},
+		"Ace-to-five triple": {6, 3, AceToFiveLow, nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var _, err = NewDrawGame(tc.players, tc.rounds, tc.scoring, rand.NewSource(1))
+			if !errors.Is(err, tc.err) {
+				t.Errorf("Expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
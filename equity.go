@@ -0,0 +1,359 @@
+package poker
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// EquityResult holds one player's outcomes from an equity calculation
+type EquityResult struct {
+	Wins   int
+	Ties   int
+	Losses int
+	Trials int
+
+	// exact is true when every possible runout was scored, so there's no
+	// sampling error at all
+	exact bool
+
+	// share is the total fraction of pots won (1 for an outright win, 1/n
+	// for an n-way tie), and shareSq is the sum of each trial's share
+	// squared so we can compute the standard error
+	share   float64
+	shareSq float64
+}
+
+// Win returns the fraction of trials this player won outright
+func (r EquityResult) Win() float64 {
+	return r.fraction(r.Wins)
+}
+
+// Tie returns the fraction of trials this player tied for the best hand
+func (r EquityResult) Tie() float64 {
+	return r.fraction(r.Ties)
+}
+
+// Lose returns the fraction of trials this player lost
+func (r EquityResult) Lose() float64 {
+	return r.fraction(r.Losses)
+}
+
+func (r EquityResult) fraction(n int) float64 {
+	if r.Trials == 0 {
+		return 0
+	}
+	return float64(n) / float64(r.Trials)
+}
+
+// Equity returns the player's share of the pot on average: wins count fully,
+// and ties count as the fraction of the pot the player would get
+func (r EquityResult) Equity() float64 {
+	if r.Trials == 0 {
+		return 0
+	}
+	return r.share / float64(r.Trials)
+}
+
+// StdErr returns the standard error of Equity, which tells you how far off a
+// Monte Carlo estimate is likely to be. The true equity is within two
+// standard errors of the estimate about 95% of the time.
+//
+// Results from ExactEquity have no sampling error, so this is always zero.
+func (r EquityResult) StdErr() float64 {
+	if r.exact || r.Trials < 2 {
+		return 0
+	}
+	var n = float64(r.Trials)
+	var mean = r.share / n
+	var variance = (r.shareSq/n - mean*mean) * n / (n - 1)
+	if variance < 0 {
+		return 0
+	}
+	return math.Sqrt(variance / n)
+}
+
+// add merges another set of outcomes for the same player into this one
+func (r *EquityResult) add(o EquityResult) {
+	r.Wins += o.Wins
+	r.Ties += o.Ties
+	r.Losses += o.Losses
+	r.Trials += o.Trials
+	r.share += o.share
+	r.shareSq += o.shareSq
+}
+
+// equityCalc holds everything we need to score runouts without allocating
+type equityCalc struct {
+	hands   []CardList
+	omaha   bool
+	board   CardList
+	known   int
+	stub    CardList
+	scratch CardList
+	scores  []uint16
+	results []EquityResult
+}
+
+// newEquityCalc validates the players' hole cards, the board, and dead cards,
+// then prepares a calculator for scoring runouts. Every hand must be the same
+// size: two cards for Texas Hold 'em, or four to six for Omaha.
+func newEquityCalc(hands []CardList, board, dead CardList) (*equityCalc, error) {
+	if len(hands) < 2 {
+		return nil, fmt.Errorf("%w: equity needs at least two hands", ErrInvalidPlayerCount)
+	}
+
+	var size = len(hands[0])
+	for _, h := range hands {
+		if len(h) != size {
+			return nil, fmt.Errorf("%w: every hand must have the same number of cards", ErrInvalidCardCount)
+		}
+	}
+	if size != 2 && (size < 4 || size > 6) {
+		return nil, fmt.Errorf("%w: hands must be two cards, or four to six for Omaha", ErrInvalidCardCount)
+	}
+	if len(board) > 5 {
+		return nil, fmt.Errorf("%w: board can't have more than five cards", ErrInvalidCardCount)
+	}
+
+	var known = append(CardList(nil), board...)
+	known = append(known, dead...)
+	for _, h := range hands {
+		known = append(known, h...)
+	}
+	var stub, err = remainingCards(known)
+	if err != nil {
+		return nil, err
+	}
+	if len(stub) < 5-len(board) {
+		return nil, fmt.Errorf("%w: not enough cards left to complete the board", ErrInvalidCardCount)
+	}
+
+	var eq = &equityCalc{
+		hands:   hands,
+		omaha:   size != 2,
+		board:   make(CardList, 5),
+		known:   len(board),
+		stub:    stub,
+		scratch: make(CardList, 7),
+		scores:  make([]uint16, len(hands)),
+		results: make([]EquityResult, len(hands)),
+	}
+	copy(eq.board, board)
+	return eq, nil
+}
+
+// remainingCards returns every card in a standard deck which isn't in the
+// known list, or an error if any known card is listed twice
+func remainingCards(known CardList) (CardList, error) {
+	var seen CardSet
+	for _, c := range known {
+		// A CardSet can't hold an invalid card, so it wouldn't be caught below
+		if c.index() < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCard, c)
+		}
+		if seen.Contains(c) {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateCard, c)
+		}
+		seen.Add(c)
+	}
+
+	return FullCardSet.Without(seen).Cards(), nil
+}
+
+// clone returns a calculator which shares this one's hands and stub, but has
+// its own board and results, so it can score runouts in another goroutine.
+// The stub must not be shuffled while any clone is in use.
+func (eq *equityCalc) clone() *equityCalc {
+	var c = &equityCalc{
+		hands:   eq.hands,
+		omaha:   eq.omaha,
+		board:   make(CardList, 5),
+		known:   eq.known,
+		stub:    eq.stub,
+		scratch: make(CardList, 7),
+		scores:  make([]uint16, len(eq.hands)),
+		results: make([]EquityResult, len(eq.hands)),
+	}
+	copy(c.board, eq.board)
+	return c
+}
+
+// enumerate scores every board which can be made by filling in positions
+// pos through 4 with stub cards, in order, starting at stub index from
+func (eq *equityCalc) enumerate(from, pos int) {
+	if pos == 5 {
+		eq.score()
+		return
+	}
+	for i := from; i <= len(eq.stub)-(5-pos); i++ {
+		eq.board[pos] = eq.stub[i]
+		eq.enumerate(i+1, pos+1)
+	}
+}
+
+// score evaluates every hand against the current board and records each
+// player's outcome. The best score and the number of players who had it are
+// returned so callers can record outcomes elsewhere, too.
+func (eq *equityCalc) score() (best uint16, winners int) {
+	best, winners = scoreHands(eq.hands, eq.board, eq.omaha, eq.scratch, eq.scores)
+	for i, s := range eq.scores {
+		eq.results[i].record(s == best, winners)
+	}
+	return best, winners
+}
+
+// scoreHands scores every hand with a full board, putting each hand's score
+// into scores. Hold 'em hands are copied into scratch, which must have room
+// for seven cards, so nothing is allocated. The best score and the number of
+// hands which had it are returned.
+func scoreHands(hands []CardList, board CardList, omaha bool, scratch CardList, scores []uint16) (best uint16, winners int) {
+	best = math.MaxUint16
+	for i, h := range hands {
+		var s uint16
+		if omaha {
+			s = h.EvaluateOmaha(board)
+		} else {
+			scratch[0], scratch[1] = h[0], h[1]
+			copy(scratch[2:], board)
+			s = scratch.Evaluate()
+		}
+		scores[i] = s
+		if s < best {
+			best = s
+		}
+	}
+
+	for _, s := range scores {
+		if s == best {
+			winners++
+		}
+	}
+	return best, winners
+}
+
+// record adds a single trial's outcome: either this player had the best hand
+// along with winners-1 others, or they lost
+func (r *EquityResult) record(best bool, winners int) {
+	r.Trials++
+	switch {
+	case !best:
+		r.Losses++
+	case winners == 1:
+		r.Wins++
+		r.share++
+		r.shareSq++
+	default:
+		var share = 1 / float64(winners)
+		r.Ties++
+		r.share += share
+		r.shareSq += share * share
+	}
+}
+
+// Equity estimates each player's chance of winning by dealing out the rest of
+// the board at random, trials times, and scoring every hand. Hands must all
+// be two cards for Texas Hold 'em, or all four to six cards for Omaha. The
+// board may have zero to five cards, and dead cards (folded or exposed cards)
+// are never dealt.
+//
+// Like NewDeck, rndSource can be any implementation of math/rand.Source, and
+// the same source and seed will always give the same results.
+//
+// Results are returned in the same order as the hands. Setup allocates a
+// little memory, but the trials themselves never do.
+func Equity(hands []CardList, board, dead CardList, trials int, rndSource rand.Source) ([]EquityResult, error) {
+	if trials < 1 {
+		return nil, fmt.Errorf("equity needs at least one trial, got %d", trials)
+	}
+
+	var eq, err = newEquityCalc(hands, board, dead)
+	if err != nil {
+		return nil, err
+	}
+
+	var rnd = rand.New(rndSource)
+	var need = 5 - eq.known
+	for i := 0; i < trials; i++ {
+		// We only need a few random cards, so a partial Fisher-Yates shuffle
+		// of the stub is plenty
+		for j := 0; j < need; j++ {
+			var k = j + rnd.Intn(len(eq.stub)-j)
+			eq.stub[j], eq.stub[k] = eq.stub[k], eq.stub[j]
+		}
+		copy(eq.board[eq.known:], eq.stub[:need])
+		eq.score()
+	}
+
+	return eq.results, nil
+}
+
+// ExactEquity works like Equity, but instead of sampling random runouts, it
+// scores every possible way the board can be completed from the cards left in
+// the deck. The results are exact, but the work grows quickly with the number
+// of cards still to come: a turn card leaves a few dozen boards, a flop about
+// a thousand, and a preflop heads-up hand about 1.7 million.
+//
+// The runouts are split up by the first card dealt and scored by up to
+// workers goroutines (or GOMAXPROCS if workers is less than one). Partial
+// results are always merged in the same order, so the results are identical
+// no matter how many workers are used.
+func ExactEquity(hands []CardList, board, dead CardList, workers int) ([]EquityResult, error) {
+	var eq, err = newEquityCalc(hands, board, dead)
+	if err != nil {
+		return nil, err
+	}
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// Each partition holds the runouts whose first new card is stub[i]
+	var partitions = 1
+	if eq.known < 5 {
+		partitions = len(eq.stub) - (5 - eq.known) + 1
+	}
+	if workers > partitions {
+		workers = partitions
+	}
+
+	var partials = make([][]EquityResult, partitions)
+	var next = make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var local = eq.clone()
+			for i := range next {
+				for j := range local.results {
+					local.results[j] = EquityResult{}
+				}
+				if local.known == 5 {
+					local.score()
+				} else {
+					local.board[local.known] = local.stub[i]
+					local.enumerate(i+1, local.known+1)
+				}
+				partials[i] = append([]EquityResult(nil), local.results...)
+			}
+		}()
+	}
+	for i := 0; i < partitions; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	var results = make([]EquityResult, len(hands))
+	for _, partial := range partials {
+		for j := range results {
+			results[j].add(partial[j])
+		}
+	}
+	for j := range results {
+		results[j].exact = true
+	}
+	return results, nil
+}
@@ -0,0 +1,242 @@
+package poker
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func mustParseHands(t testing.TB, hands ...string) []CardList {
+	var lists = make([]CardList, len(hands))
+	for i, h := range hands {
+		var cl, err = ParseCards(h)
+		if err != nil {
+			t.Fatalf("Unable to parse %q: %s", h, err)
+		}
+		lists[i] = cl
+	}
+	return lists
+}
+
+func TestEquity(t *testing.T) {
+	// Expected equities are exact, from ExactEquity, so a sample should land
+	// within a few standard errors of them
+	var tests = map[string]struct {
+		hands  []string
+		board  string
+		dead   string
+		equity []float64
+	}{
+		"Aces vs. kings":         {[]string{"As Ah", "Ks Kh"}, "", "", []float64{0.8264, 0.1736}},
+		"Suited AK vs. queens":   {[]string{"Ah Kh", "Qs Qc"}, "", "", []float64{0.4621, 0.5379}},
+		"Flush draw on the flop": {[]string{"Ah Kh", "Qs Qc"}, "2h 7h 9c", "", []float64{0.5414, 0.4586}},
+		"Dead kings":             {[]string{"As Ah", "Ks Kh"}, "", "Kd Kc", []float64{0.9879, 0.0121}},
+		"Omaha":                  {[]string{"As Ah Ks Kh", "Qd Jd Td 9c"}, "", "", []float64{0.6690, 0.3310}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hands = mustParseHands(t, tc.hands...)
+			var board, _ = ParseCards(tc.board)
+			var dead, _ = ParseCards(tc.dead)
+
+			var results, err = Equity(hands, board, dead, 20000, rand.NewSource(1))
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			for i, r := range results {
+				if r.Trials != 20000 {
+					t.Errorf("Player %d: expected 20000 trials, got %d", i, r.Trials)
+				}
+				if r.Wins+r.Ties+r.Losses != r.Trials {
+					t.Errorf("Player %d: wins, ties, and losses don't add up to trials: %#v", i, r)
+				}
+				if math.Abs(r.Equity()-tc.equity[i]) > 3*r.StdErr() {
+					t.Errorf("Player %d: equity %0.4f (±%0.4f) is too far from the expected %0.4f",
+						i, r.Equity(), r.StdErr(), tc.equity[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEquityTies(t *testing.T) {
+	// Broadway on the board means everybody chops
+	var hands = mustParseHands(t, "2c 3d", "4h 5s", "7c 8d")
+	var board, _ = ParseCards("As Ks Qh Jd Tc")
+	var results, err = Equity(hands, board, nil, 100, rand.NewSource(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for i, r := range results {
+		if r.Ties != 100 || r.Tie() != 1 {
+			t.Errorf("Player %d: expected 100 ties, got %d", i, r.Ties)
+		}
+		if math.Abs(r.Equity()-1.0/3) > 1e-9 {
+			t.Errorf("Player %d: expected equity of 1/3, got %f", i, r.Equity())
+		}
+		if r.StdErr() > 1e-9 {
+			t.Errorf("Player %d: expected no error with a fixed result, got %f", i, r.StdErr())
+		}
+	}
+}
+
+func TestEquityDeterministic(t *testing.T) {
+	var hands = mustParseHands(t, "Ah Kh", "Qs Qc", "7d 6d")
+	var a, _ = Equity(hands, nil, nil, 1000, rand.NewSource(42))
+	var b, _ = Equity(hands, nil, nil, 1000, rand.NewSource(42))
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("Player %d: same seed gave different results: %#v vs. %#v", i, a[i], b[i])
+		}
+	}
+}
+
+func TestEquityErrors(t *testing.T) {
+	var tests = map[string]struct {
+		hands  []string
+		board  string
+		dead   string
+		trials int
+		err    error
+	}{
+		"One player":      {[]string{"As Ah"}, "", "", 10, ErrInvalidPlayerCount},
+		"Mixed sizes":     {[]string{"As Ah", "Ks Kh Qs Qh"}, "", "", 10, ErrInvalidCardCount},
+		"Three cards":     {[]string{"As Ah 2c", "Ks Kh 2d"}, "", "", 10, ErrInvalidCardCount},
+		"Six-card board":  {[]string{"As Ah", "Ks Kh"}, "2c 3c 4c 5c 6c 7c", "", 10, ErrInvalidCardCount},
+		"Shared card":     {[]string{"As Ah", "As Kh"}, "", "", 10, ErrDuplicateCard},
+		"Dead hole card":  {[]string{"As Ah", "Ks Kh"}, "", "Kh", 10, ErrDuplicateCard},
+		"Board hole card": {[]string{"As Ah", "Ks Kh"}, "2c 3c Ah", "", 10, ErrDuplicateCard},
+		"No trials":       {[]string{"As Ah", "Ks Kh"}, "", "", 0, nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hands = mustParseHands(t, tc.hands...)
+			var board, _ = ParseCards(tc.board)
+			var dead, _ = ParseCards(tc.dead)
+			var _, err = Equity(hands, board, dead, tc.trials, rand.NewSource(1))
+			if err == nil {
+				t.Fatalf("Expected an error, got nil")
+			}
+			if tc.err != nil && !errors.Is(err, tc.err) {
+				t.Fatalf("Expected error to be %q, got %q", tc.err, err)
+			}
+		})
+	}
+
+	// The zero Card isn't a card at all
+	var hands = mustParseHands(t, "As Ah", "Ks Kh")
+	var _, err = Equity(hands, CardList{0}, nil, 10, rand.NewSource(1))
+	if !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("Expected a zero board card to be %q, got %v", ErrInvalidCard, err)
+	}
+	hands[1][0] = 0
+	_, err = ExactEquity(hands, nil, nil, 1)
+	if !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("Expected a zero hole card to be %q, got %v", ErrInvalidCard, err)
+	}
+}
+
+func TestEquityTrialsDontAllocate(t *testing.T) {
+	var hands = mustParseHands(t, "Ah Kh", "Qs Qc")
+	var omaha = mustParseHands(t, "As Ah Ks Kh", "Qd Jd Td 9c")
+	var src = rand.NewSource(1)
+
+	var few = testing.AllocsPerRun(10, func() { Equity(hands, nil, nil, 10, src) })
+	var many = testing.AllocsPerRun(10, func() { Equity(hands, nil, nil, 1000, src) })
+	if few != many {
+		t.Errorf("Hold'em: 10 trials made %0.f allocations, but 1000 trials made %0.f", few, many)
+	}
+
+	few = testing.AllocsPerRun(10, func() { Equity(omaha, nil, nil, 10, src) })
+	many = testing.AllocsPerRun(10, func() { Equity(omaha, nil, nil, 1000, src) })
+	if few != many {
+		t.Errorf("Omaha: 10 trials made %0.f allocations, but 1000 trials made %0.f", few, many)
+	}
+}
+
+func BenchmarkEquity(b *testing.B) {
+	var hands = mustParseHands(b, "Ah Kh", "Qs Qc")
+	var src = rand.NewSource(1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Equity(hands, nil, nil, 1000, src)
+	}
+}
+
+func TestExactEquity(t *testing.T) {
+	var tests = map[string]struct {
+		hands  []string
+		board  string
+		dead   string
+		trials int
+		wins   []int
+		ties   []int
+	}{
+		"Flush draw on the flop": {[]string{"Ah Kh", "Qs Qc"}, "2h 7h 9c", "", 990, []int{536, 454}, []int{0, 0}},
+		"Turn":                   {[]string{"Ah Kh", "Qs Qc"}, "2h 7h 9c 3d", "", 44, []int{15, 29}, []int{0, 0}},
+		"River":                  {[]string{"Ah Kh", "Qs Qc"}, "2h 7h 9c 3d Kd", "", 1, []int{1, 0}, []int{0, 0}},
+		"Chopped river":          {[]string{"2c 3d", "4h 5s"}, "As Ks Qh Jd Tc", "", 1, []int{0, 0}, []int{1, 1}},
+		"Dead outs":              {[]string{"Ah Kh", "Qs Qc"}, "2h 7h 9c 3d", "4h 5h 6h 8h Th Jh Qh Ad Ac As Kd Kc Ks", 31, []int{2, 29}, []int{0, 0}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hands = mustParseHands(t, tc.hands...)
+			var board, _ = ParseCards(tc.board)
+			var dead, _ = ParseCards(tc.dead)
+
+			var results, err = ExactEquity(hands, board, dead, 4)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			for i, r := range results {
+				if r.Trials != tc.trials {
+					t.Errorf("Player %d: expected %d runouts, got %d", i, tc.trials, r.Trials)
+				}
+				if r.Wins != tc.wins[i] || r.Ties != tc.ties[i] {
+					t.Errorf("Player %d: expected %d wins and %d ties, got %d and %d", i, tc.wins[i], tc.ties[i], r.Wins, r.Ties)
+				}
+				if r.StdErr() != 0 {
+					t.Errorf("Player %d: exact results shouldn't have a standard error, got %f", i, r.StdErr())
+				}
+			}
+		})
+	}
+}
+
+func TestExactEquityWorkers(t *testing.T) {
+	var hands = mustParseHands(t, "Ah Kh", "Qs Qc", "7d 6d")
+	var board, _ = ParseCards("2h 7h 9c")
+	var serial, _ = ExactEquity(hands, board, nil, 1)
+	for _, workers := range []int{0, 2, 3, 16, 1000} {
+		var parallel, _ = ExactEquity(hands, board, nil, workers)
+		for i := range serial {
+			if serial[i] != parallel[i] {
+				t.Fatalf("%d workers, player %d: expected %#v, got %#v", workers, i, serial[i], parallel[i])
+			}
+		}
+	}
+}
+
+func TestExactEquityPreflop(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping preflop enumeration in short mode")
+	}
+
+	var hands = mustParseHands(t, "As Ah", "Ks Kh")
+	var results, err = ExactEquity(hands, nil, nil, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if results[0].Trials != 1712304 {
+		t.Fatalf("Expected 1712304 runouts, got %d", results[0].Trials)
+	}
+	if results[0].Wins != 1410336 || results[0].Ties != 9308 || results[1].Wins != 292660 {
+		t.Fatalf("Expected 1410336 wins for aces, 292660 for kings, and 9308 ties; got %#v", results)
+	}
+}
@@ -6,8 +6,25 @@ type PokerError string
 
 // Hand errors
 const (
-	ErrEmptyDeck        PokerError = "cannot draw from empty deck"
-	ErrInvalidCardCount PokerError = "invalid card count"
+	ErrEmptyDeck          PokerError = "cannot draw from empty deck"
+	ErrInvalidCardCount   PokerError = "invalid card count"
+	ErrInvalidTableData   PokerError = "invalid lookup table data"
+	ErrUnknownScoring     PokerError = "unknown scoring rules"
+	ErrInvalidCard        PokerError = "card is not valid for this game"
+	ErrDuplicateCard      PokerError = "card appears more than once"
+	ErrInvalidPlayerCount PokerError = "invalid number of players"
+	ErrInvalidRange       PokerError = "invalid hand range"
+	ErrCardNotInHand      PokerError = "card is not in the hand"
+)
+
+// Table errors
+const (
+	ErrInvalidSeat      PokerError = "invalid seat"
+	ErrSeatTaken        PokerError = "seat is already taken"
+	ErrInvalidAmount    PokerError = "invalid chip amount"
+	ErrHandInProgress   PokerError = "a hand is already in progress"
+	ErrNoHandInProgress PokerError = "no hand is in progress"
+	ErrIllegalAction    PokerError = "illegal action"
 )
 
 func (e PokerError) Error() string {
@@ -1,5 +1,7 @@
 package poker
 
+//go:generate go run ./cmd/gendata -o data.go
+
 import (
 	"math"
 )
@@ -122,7 +124,9 @@ var perms7 = [][5]int{
 	{2, 3, 4, 5, 6},
 }
 
-// all permutations of the four hole cards in Omaha - exactly two must be used
+// all permutations of the hole cards in Omaha - exactly two must be used. With
+// four hole cards, only the first 6 permutations have any meaning. Five-card
+// PLO uses the first 10, and six-card PLO uses them all.
 var omahaHolePerms = [][2]int{
 	{0, 1},
 	{0, 2},
@@ -130,6 +134,29 @@ var omahaHolePerms = [][2]int{
 	{1, 2},
 	{1, 3},
 	{2, 3},
+	{0, 4},
+	{1, 4},
+	{2, 4},
+	{3, 4},
+	{0, 5},
+	{1, 5},
+	{2, 5},
+	{3, 5},
+	{4, 5},
+}
+
+// omahaHolePermsFor returns the hole-card permutations for a hand of n hole
+// cards, or nil if n isn't a valid Omaha hand size
+func omahaHolePermsFor(n int) [][2]int {
+	switch n {
+	case 4:
+		return omahaHolePerms[:6]
+	case 5:
+		return omahaHolePerms[:10]
+	case 6:
+		return omahaHolePerms
+	}
+	return nil
 }
 
 // all permutations of the five community cards in Omaha - exactly three must
@@ -148,6 +175,88 @@ var omahaCommunityPerms = [][3]int{
 	{2, 3, 4},
 }
 
+// fiveCardEval is any function which scores exactly five cards, where a lower
+// score is a better hand
+type fiveCardEval func(c1, c2, c3, c4, c5 Card) uint16
+
+// bestHandWith is BestHand for any five-card scoring function
+func (cl CardList) bestHandWith(eval fiveCardEval) (score uint16, best [5]Card) {
+	score = math.MaxUint16
+	var perms [][5]int
+	switch len(cl) {
+	case 5:
+		perms = perms7[:1]
+	case 6:
+		perms = perms6
+	case 7:
+		perms = perms7
+	default:
+		return
+	}
+
+	for _, perm := range perms {
+		var val = eval(
+			cl[perm[0]],
+			cl[perm[1]],
+			cl[perm[2]],
+			cl[perm[3]],
+			cl[perm[4]],
+		)
+		if val < score {
+			score = val
+			best[0] = cl[perm[0]]
+			best[1] = cl[perm[1]]
+			best[2] = cl[perm[2]]
+			best[3] = cl[perm[3]]
+			best[4] = cl[perm[4]]
+		}
+	}
+
+	return
+}
+
+// bestOmahaHandWith is BestOmahaHand for any five-card scoring function
+func (cl CardList) bestOmahaHandWith(community CardList, eval fiveCardEval) (score uint16, bestH [2]Card, bestC [3]Card) {
+	score = math.MaxUint16
+	var hPerms = omahaHolePermsFor(len(cl))
+	if hPerms == nil {
+		return
+	}
+
+	var cPerms [][3]int
+	switch len(community) {
+	case 3:
+		cPerms = omahaCommunityPerms[:1]
+	case 4:
+		cPerms = omahaCommunityPerms[:4]
+	case 5:
+		cPerms = omahaCommunityPerms
+	default:
+		return
+	}
+
+	for _, holeP := range hPerms {
+		for _, commP := range cPerms {
+			var val = eval(
+				cl[holeP[0]],
+				cl[holeP[1]],
+				community[commP[0]],
+				community[commP[1]],
+				community[commP[2]],
+			)
+			if val < score {
+				bestH[0] = cl[holeP[0]]
+				bestH[1] = cl[holeP[1]]
+				bestC[0] = community[commP[0]]
+				bestC[1] = community[commP[1]]
+				bestC[2] = community[commP[2]]
+				score = val
+			}
+		}
+	}
+	return
+}
+
 func (cl CardList) evalMore() uint16 {
 	var minimum uint16 = math.MaxUint16
 
@@ -179,7 +288,16 @@ func (cl CardList) evalMore() uint16 {
 // the river. But the rules require you to use exactly two of your hole cards
 // to make a hand.  This might seem complicated, but it drastically reduces the
 // permutations compared to a full nine-card evaluation.
+//
+// Five-card PLO (Big O) and six-card PLO are supported as well: the hole
+// cards may be four, five, or six cards, and exactly two must still be used.
+// Any other number of hole cards returns math.MaxUint16.
 func (cl CardList) EvaluateOmaha(community CardList) uint16 {
+	var hPerms = omahaHolePermsFor(len(cl))
+	if hPerms == nil {
+		return math.MaxUint16
+	}
+
 	var cPerms [][3]int
 	switch len(community) {
 	case 3:
@@ -193,7 +311,7 @@ func (cl CardList) EvaluateOmaha(community CardList) uint16 {
 	}
 
 	var minimum uint16 = math.MaxUint16
-	for _, holeP := range omahaHolePerms {
+	for _, holeP := range hPerms {
 		for _, commP := range cPerms {
 			var score = evalFiveFast(
 				cl[holeP[0]],
@@ -217,6 +335,11 @@ func (cl CardList) EvaluateOmaha(community CardList) uint16 {
 // many permutations of Omaha hand possibilities.
 func (cl CardList) BestOmahaHand(community []Card) (score uint16, bestH [2]Card, bestC [3]Card) {
 	score = math.MaxUint16
+	var hPerms = omahaHolePermsFor(len(cl))
+	if hPerms == nil {
+		return
+	}
+
 	var cPerms [][3]int
 	switch len(community) {
 	case 3:
@@ -229,7 +352,7 @@ func (cl CardList) BestOmahaHand(community []Card) (score uint16, bestH [2]Card,
 		return math.MaxUint16, bestH, bestC
 	}
 
-	for _, holeP := range omahaHolePerms {
+	for _, holeP := range hPerms {
 		for _, commP := range cPerms {
 			var eval = evalFiveFast(
 				cl[holeP[0]],
@@ -220,6 +220,82 @@ func TestBestOmahaHand(t *testing.T) {
 	}
 }
 
+func TestBigOmaha(t *testing.T) {
+	var tests = map[string]struct {
+		hole      string
+		community string
+		handValue uint16
+		bestHole  string
+		bestComm  string
+	}{
+		"Five: fifth card plays": {"As 2d Kc Kh 3s", "7c 8d Ks Qd 5h", 1690, "Kc Kh", "8d Ks Qd"},
+		"Six: sixth card plays":  {"Kc Kh 9s 9d Ac 6c", "Ks 9c 2h 3c Jc", 380, "Kc Ac", "9c 3c Jc"},
+		"Five: one heart only":   {"Ah 7d 2c 3c Qs", "Kh Qh Jh Th 4c", 1600, "Ah Qs", "Kh Jh Th"},
+		"Six: flop":              {"2c 7h 4d Ad 2h 7s", "2d 8c 7d", 2127, "7h 7s", "2d 8c 7d"},
+		"Four cards still work":  {"2c 7h 4d Ad", "2d 8c 2h Ks 4s", 321, "2c 4d", "2d 2h 4s"},
+		"Too many hole cards":    {"2c 7h 4d Ad 3s 5s 6s", "2d 8c 2h Ks 4s", math.MaxUint16, "", ""},
+		"Too few hole cards":     {"2c 7h 4d", "2d 8c 2h Ks 4s", math.MaxUint16, "", ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hole, _ = ParseCards(tc.hole)
+			var community, _ = ParseCards(tc.community)
+
+			var handVal = hole.EvaluateOmaha(community)
+			if handVal != tc.handValue {
+				t.Fatalf("%s,%s gave a hand value of %d; expected %d", hole, community, handVal, tc.handValue)
+			}
+
+			var score, bestH, bestC = hole.BestOmahaHand(community)
+			if score != handVal {
+				t.Fatalf("%s,%s: BestOmahaHand scored %d, but EvaluateOmaha scored %d", hole, community, score, handVal)
+			}
+			if score == math.MaxUint16 {
+				return
+			}
+			var gotHole = CardList(bestH[:]).String()
+			var gotComm = CardList(bestC[:]).String()
+			if gotHole != tc.bestHole || gotComm != tc.bestComm {
+				t.Fatalf("%s,%s: expected %s,%s to be best, but got %s,%s", hole, community, tc.bestHole, tc.bestComm, gotHole, gotComm)
+			}
+		})
+	}
+}
+
+// Any two hole cards in a five- or six-card Omaha hand are also two hole cards
+// in some four-card subset, so the best four-card hand must match
+func TestBigOmahaMatchesFourCardSubsets(t *testing.T) {
+	var deck = NewDeck(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		deck.Shuffle()
+		var holeCount = 5 + i%2
+		var cards = deck.Draw(holeCount + 5)
+		var hole, community = cards[:holeCount], cards[holeCount:]
+
+		var want uint16 = math.MaxUint16
+		for a := 0; a < holeCount; a++ {
+			for b := a + 1; b < holeCount; b++ {
+				for c := b + 1; c < holeCount; c++ {
+					for d := c + 1; d < holeCount; d++ {
+						var sub = CardList{hole[a], hole[b], hole[c], hole[d]}
+						var score = sub.EvaluateOmaha(community)
+						if score < want {
+							want = score
+						}
+					}
+				}
+			}
+		}
+
+		var got = hole.EvaluateOmaha(community)
+		if got != want {
+			t.Fatalf("%s,%s gave a hand value of %d; expected %d", hole, community, got, want)
+		}
+		deck.Reset()
+	}
+}
+
 func BenchmarkEvalFiveFast(b *testing.B) {
 	var deck *Deck
 	var hands = make([]CardList, 100)
@@ -315,3 +391,19 @@ func BenchmarkBestOmahaHand(b *testing.B) {
 		CardList(hand[:4]).EvaluateOmaha(hand[4:])
 	}
 }
+
+func BenchmarkEvaluateSixCardOmaha(b *testing.B) {
+	var deck *Deck
+	var hands = make([]CardList, 100)
+	for i := 0; i < 100; i++ {
+		deck = NewDeck(rand.NewSource(time.Now().UnixNano()))
+		deck.Shuffle()
+		hands[i] = deck.Draw(11)
+	}
+
+	var hl = len(hands)
+	for i := 0; i < b.N; i++ {
+		var hand = hands[i%hl]
+		CardList(hand[:6]).EvaluateOmaha(hand[6:])
+	}
+}
@@ -0,0 +1,152 @@
+package poker
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// naiveStrength is a deliberately simple (and slow) five-card evaluator which
+// shares no code or data with evalFiveFast. It returns a number which is
+// higher for better hands, and the hand's rank.
+func naiveStrength(cards [5]Card) (int, HandRank) {
+	var counts [13]int
+	var flush = true
+	for _, c := range cards {
+		counts[c.Rank()]++
+		if c.Suit() != cards[0].Suit() {
+			flush = false
+		}
+	}
+
+	// Order ranks by how many we have of each, then by the rank itself
+	var ordered []CardRank
+	for n := 4; n > 0; n-- {
+		for r := int(Ace); r >= int(Deuce); r-- {
+			if counts[r] == n {
+				ordered = append(ordered, CardRank(r))
+			}
+		}
+	}
+
+	// A straight has five distinct ranks spanning exactly five, or the wheel
+	var straightHigh = -1
+	if len(ordered) == 5 {
+		if ordered[0]-ordered[4] == 4 {
+			straightHigh = int(ordered[0])
+		}
+		if ordered[0] == Ace && ordered[1] == Five {
+			straightHigh = int(Five)
+		}
+	}
+
+	var category int
+	var rank HandRank
+	switch {
+	case straightHigh >= 0 && flush:
+		category, rank = 8, StraightFlush
+	case counts[ordered[0]] == 4:
+		category, rank = 7, FourOfAKind
+	case counts[ordered[0]] == 3 && counts[ordered[1]] == 2:
+		category, rank = 6, FullHouse
+	case flush:
+		category, rank = 5, Flush
+	case straightHigh >= 0:
+		category, rank = 4, Straight
+	case counts[ordered[0]] == 3:
+		category, rank = 3, ThreeOfAKind
+	case counts[ordered[0]] == 2 && counts[ordered[1]] == 2:
+		category, rank = 2, TwoPair
+	case counts[ordered[0]] == 2:
+		category, rank = 1, OnePair
+	default:
+		category, rank = 0, HighCard
+	}
+
+	// Tiebreakers are the ordered ranks, except straights only care about the
+	// high card (which matters for the wheel)
+	var strength = category
+	for i := 0; i < 5; i++ {
+		strength *= 13
+		if straightHigh >= 0 {
+			if i == 0 {
+				strength += straightHigh
+			}
+		} else if i < len(ordered) {
+			strength += int(ordered[i])
+		}
+	}
+
+	return strength, rank
+}
+
+func TestAllFiveCardHands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping exhaustive five-card test in short mode")
+	}
+
+	var deck = NewDeck(rand.NewSource(0)).cards
+	var rankCounts = make(map[HandRank]int)
+	var strengths = make(map[uint16]int)
+	var total int
+
+	for a := 0; a < 52; a++ {
+		for b := a + 1; b < 52; b++ {
+			for c := b + 1; c < 52; c++ {
+				for d := c + 1; d < 52; d++ {
+					for e := d + 1; e < 52; e++ {
+						var hand = [5]Card{deck[a], deck[b], deck[c], deck[d], deck[e]}
+						var score = evalFiveFast(hand[0], hand[1], hand[2], hand[3], hand[4])
+						var strength, rank = naiveStrength(hand)
+						total++
+						rankCounts[GetHandRank(score)]++
+
+						if GetHandRank(score) != rank {
+							t.Fatalf("%s: score %d is a %s, but the reference evaluator says %s",
+								CardList(hand[:]), score, GetHandRank(score), rank)
+						}
+						if prev, ok := strengths[score]; ok && prev != strength {
+							t.Fatalf("%s: score %d was given to hands of different strength", CardList(hand[:]), score)
+						}
+						strengths[score] = strength
+					}
+				}
+			}
+		}
+	}
+
+	if total != 2598960 {
+		t.Fatalf("Evaluated %d hands; expected 2598960", total)
+	}
+	if len(strengths) != 7462 {
+		t.Fatalf("Got %d distinct scores; expected 7462", len(strengths))
+	}
+
+	var expected = map[HandRank]int{
+		StraightFlush: 40,
+		FourOfAKind:   624,
+		FullHouse:     3744,
+		Flush:         5108,
+		Straight:      10200,
+		ThreeOfAKind:  54912,
+		TwoPair:       123552,
+		OnePair:       1098240,
+		HighCard:      1302540,
+	}
+	for rank, want := range expected {
+		if rankCounts[rank] != want {
+			t.Errorf("Got %d hands with rank %s; expected %d", rankCounts[rank], rank, want)
+		}
+	}
+
+	// Every score from 1 to 7462 must be used, and a lower score must always
+	// mean a strictly stronger hand
+	for score := uint16(1); score <= 7462; score++ {
+		var strength, ok = strengths[score]
+		if !ok {
+			t.Fatalf("No hand had score %d", score)
+		}
+		if score > 1 && strengths[score-1] <= strength {
+			t.Fatalf("Score %d isn't stronger than score %d according to the reference evaluator", score-1, score)
+		}
+	}
+}
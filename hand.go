@@ -41,27 +41,25 @@ func (h *Hand) String() string {
 // cards but community cards were offered up, etc.), the score will be the
 // worst possible (MaxUint16), and there will be no description of the hand.
 func (h *Hand) Evaluate(community ...Card) (hr *HandResult, err error) {
-	hr = &HandResult{}
+	return h.EvaluateAs(HighScoring, community...)
+}
 
-	// Copy cards, don't just reuse the slices
-	hr.Hand = make(CardList, len(h.cards))
-	copy(hr.Hand, h.cards)
-	hr.Community = make(CardList, len(community))
-	copy(hr.Community, community)
+// EvaluateAs works just like Evaluate, but scores the hand using the given
+// rules, e.g., AceToFiveLow for Razz or ShortDeckHigh for short-deck Hold 'em.
+// Hole cards and community cards are combined following the same rules as
+// Evaluate.
+func (h *Hand) EvaluateAs(s Scoring, community ...Card) (hr *HandResult, err error) {
+	if s.String() == "" {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownScoring, s)
+	}
 
-	if len(community) == 0 {
-		err = hr.evaluateRaw()
-	} else {
-		switch len(h.cards) {
-		case 2:
-			err = hr.evaluateTexas()
-		case 4:
-			err = hr.evaluateOmaha()
-		default:
-			return nil, fmt.Errorf("%w: hole cards must be two or four when community cards are present", ErrInvalidCardCount)
-		}
+	err = s.checkCards(h.cards, community)
+	if err != nil {
+		return nil, err
 	}
 
+	hr = h.newResult(s, community)
+	err = hr.evaluate(s.fiveCardEval())
 	if err != nil {
 		return nil, fmt.Errorf("error evaluating hand: %w", err)
 	}
@@ -69,13 +67,94 @@ func (h *Hand) Evaluate(community ...Card) (hr *HandResult, err error) {
 		return nil, fmt.Errorf("unknown error evaluating hand")
 	}
 
-	hr.Best5 = CardList(hr.best[:])
-	hr.Rank = GetHandRank(hr.Score)
-	hr.sort()
+	hr.finish()
+	return hr, nil
+}
+
+// EvaluateHiLo computes the best high hand just like Evaluate, and also the
+// best eight-or-better low for split-pot games. The low half is stored in the
+// returned result's Low field, which is nil if no qualifying low exists.
+//
+// With community cards, this is Omaha Hi-Lo: four hole cards (or five for Big
+// O, or six), exactly two of which must be used for each half. Without
+// community cards, it's a raw hand of five to seven cards, as in Seven-Card
+// Stud Hi-Lo, where any five cards can make the high and any five can make
+// the low.
+func (h *Hand) EvaluateHiLo(community ...Card) (hr *HandResult, err error) {
+	if len(community) != 0 && (len(h.cards) < 4 || len(h.cards) > 6) {
+		return nil, fmt.Errorf("%w: hi-lo needs four to six hole cards when community cards are present", ErrInvalidCardCount)
+	}
+
+	hr, err = h.Evaluate(community...)
+	if err != nil {
+		return nil, err
+	}
+
+	var low = h.newResult(AceToFiveLow, community)
+	err = low.evaluate(evalEightOrBetter)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating low hand: %w", err)
+	}
+	if low.Score != math.MaxUint16 {
+		low.finish()
+		hr.Low = low
+	}
+
 	return hr, nil
 }
 
+// newResult returns a HandResult holding copies of this hand's cards and the
+// community cards, ready to be evaluated
+func (h *Hand) newResult(s Scoring, community []Card) *HandResult {
+	var hr = &HandResult{Scoring: s}
+
+	// Copy cards, don't just reuse the slices
+	hr.Hand = make(CardList, len(h.cards))
+	copy(hr.Hand, h.cards)
+	hr.Community = make(CardList, len(community))
+	copy(hr.Community, community)
+
+	return hr
+}
+
 // AddCard puts the card into this player's hand
 func (h *Hand) AddCard(c Card) {
 	h.cards = append(h.cards, c)
 }
+
+// Replace discards the given cards and deals the same number of new ones from
+// the deck, as in a draw game. Replacements are dealt before the discards are
+// mucked, so a player can never draw their own discards back. Discarding no
+// cards is standing pat.
+//
+// Nothing changes if any discard isn't in the hand, or if the deck and its
+// muck together can't cover the draw.
+func (h *Hand) Replace(deck *Deck, discards ...Card) error {
+	var set CardSet
+	for _, c := range discards {
+		if set.Contains(c) {
+			return fmt.Errorf("%w: %s", ErrDuplicateCard, c)
+		}
+		set.Add(c)
+	}
+
+	var kept = make(CardList, 0, len(h.cards))
+	for _, c := range h.cards {
+		if !set.Contains(c) {
+			kept = append(kept, c)
+		}
+	}
+	if len(kept)+len(discards) != len(h.cards) {
+		return fmt.Errorf("%w: can't discard %s from %s", ErrCardNotInHand, CardList(discards), h)
+	}
+	if deck.Count()+len(deck.muck) < len(discards) {
+		return fmt.Errorf("%w: need %d cards", ErrEmptyDeck, len(discards))
+	}
+
+	h.cards = kept
+	for range discards {
+		deck.Deal(h)
+	}
+	deck.Muck(discards...)
+	return nil
+}
@@ -1,10 +1,19 @@
 package poker
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // HandResult is the complex data created after analyzing a hand. It contains
 // the source cards (user and community), the five cards that made the best
 // hand, sorted for readability, a raw score, and a human-friendly description.
+//
+// Scoring tells you which rules were used to compute the score. Scores from
+// different rules can't be compared to one another.
+//
+// Low is only set for split-pot games (see Hand.EvaluateHiLo), and holds the
+// low half of the hand, or nil if there's no qualifying low.
 type HandResult struct {
 	Hand      CardList
 	Community CardList
@@ -12,39 +21,65 @@ type HandResult struct {
 	Best5     CardList
 	Rank      HandRank
 	Score     uint16
+	Scoring   Scoring
+	Low       *HandResult
+}
+
+// evaluate scores the hand using the given evaluator. The hand is treated as
+// raw cards when there are no community cards, otherwise the hole cards are
+// combined with the community cards per Texas Hold 'em or Omaha rules.
+func (hr *HandResult) evaluate(eval fiveCardEval) error {
+	if len(hr.Community) == 0 {
+		return hr.evaluateRaw(eval)
+	}
+
+	switch len(hr.Hand) {
+	case 2:
+		return hr.evaluateTexas(eval)
+	case 4, 5, 6:
+		return hr.evaluateOmaha(eval)
+	}
+	return fmt.Errorf("%w: hole cards must be two, four, five, or six when community cards are present", ErrInvalidCardCount)
 }
 
-func (hr *HandResult) evaluateRaw() error {
+func (hr *HandResult) evaluateRaw(eval fiveCardEval) error {
 	if len(hr.Hand) < 5 || len(hr.Hand) > 7 {
 		return fmt.Errorf("evaluateRaw(): %w", ErrInvalidCardCount)
 	}
 
-	hr.Score, hr.best = hr.Hand.BestHand()
+	hr.Score, hr.best = hr.Hand.bestHandWith(eval)
 	return nil
 }
 
-func (hr *HandResult) evaluateTexas() error {
+func (hr *HandResult) evaluateTexas(eval fiveCardEval) error {
 	if len(hr.Community) < 3 || len(hr.Community) > 5 {
 		return fmt.Errorf("evaluateTexas(): %w", ErrInvalidCardCount)
 	}
 
-	var eval = append(hr.Hand, hr.Community...)
-	hr.Score, hr.best = eval.BestHand()
+	var cards = append(hr.Hand, hr.Community...)
+	hr.Score, hr.best = cards.bestHandWith(eval)
 	return nil
 }
 
-func (hr *HandResult) evaluateOmaha() error {
+func (hr *HandResult) evaluateOmaha(eval fiveCardEval) error {
 	if len(hr.Community) < 3 || len(hr.Community) > 5 {
 		return fmt.Errorf("evaluateOmaha(): %w", ErrInvalidCardCount)
 	}
 
 	var bestH [2]Card
 	var bestC [3]Card
-	hr.Score, bestH, bestC = hr.Hand.BestOmahaHand(hr.Community)
+	hr.Score, bestH, bestC = hr.Hand.bestOmahaHandWith(hr.Community, eval)
 	hr.best = [5]Card{bestH[0], bestH[1], bestC[0], bestC[1], bestC[2]}
 	return nil
 }
 
+// finish fills in the human-friendly data once a valid score is computed
+func (hr *HandResult) finish() {
+	hr.Best5 = CardList(hr.best[:])
+	hr.Rank = hr.Scoring.handRank(hr.Score)
+	hr.sort()
+}
+
 // sort takes the hand result and makes it human-friendly based on its rank.
 // "sorts" the best hand's cards so they're easy to read (e.g., "Ah 2d Ac 3s
 // As" becomes "Ah Ac As 3s 2d") and marks the hand as having been evaluated.
@@ -52,14 +87,21 @@ func (hr *HandResult) evaluateOmaha() error {
 // If the score was invalid upon calling this method, no sorting takes place
 // and "evaluated" is set to false.
 func (hr *HandResult) sort() {
-	switch GetHandRank(hr.Score) {
-	case StraightFlush, Straight:
-		// If we have two cards both greater than five, Ace must be high, otherwise
-		// it's low
-		if hr.Best5[0].Rank() > Five && hr.Best5[1].Rank() > Five {
-			hr.Best5.SortAceHigh()
+	if hr.Scoring == AceToFiveLow {
+		if hr.Rank == HighCard {
+			hr.Best5.SortAceLow()
 		} else {
+			hr.Best5.SortGroups()
+		}
+		return
+	}
+
+	switch hr.Rank {
+	case StraightFlush, Straight:
+		if hr.isWheel() {
 			hr.Best5.SortAceLow()
+		} else {
+			hr.Best5.SortAceHigh()
 		}
 	case FourOfAKind, FullHouse, ThreeOfAKind, TwoPair, OnePair:
 		hr.Best5.SortGroups()
@@ -68,12 +110,41 @@ func (hr *HandResult) sort() {
 	}
 }
 
+// isWheel returns true if the best hand is the lowest possible straight, where
+// the ace plays low: A-2-3-4-5 normally, or A-6-7-8-9 in short-deck
+func (hr *HandResult) isWheel() bool {
+	var hasAce, hasLow bool
+	var low = Five
+	if hr.Scoring == ShortDeckHigh {
+		low = Six
+	}
+	for _, c := range hr.Best5 {
+		hasAce = hasAce || c.Rank() == Ace
+		hasLow = hasLow || c.Rank() == low
+	}
+	return hasAce && hasLow
+}
+
 // Describe gives an explanation about the hand: "Full House, Aces Over Kings",
 // "Two pair, Kings And Threes", etc.
 //
 // If this is called without one of the Evaluate methods first having been
 // called, the hand is described as "N/A".
+//
+// Unpaired lowball hands are described by their cards from highest to lowest,
+// e.g., "7-5-4-3-2". The four best deuce-to-seven hands get their traditional
+// names as well, e.g., "Number One: 7-5-4-3-2".
 func (hr *HandResult) Describe() string {
+	if hr.Scoring == AceToFiveLow && hr.Rank == HighCard {
+		return hr.describeLow()
+	}
+	if hr.Scoring == DeuceToSevenLow && hr.Rank == HighCard {
+		if int(hr.Score) < len(deuceToSevenNames) {
+			return deuceToSevenNames[hr.Score] + ": " + hr.describeLow()
+		}
+		return hr.describeLow()
+	}
+
 	var high = hr.Best5[0].Rank()
 	var low = hr.Best5[4].Rank()
 	var base = hr.Rank.String()
@@ -102,3 +173,17 @@ func (hr *HandResult) Describe() string {
 
 	panic("ERROR: Unknown hand rank!")
 }
+
+// deuceToSevenNames holds the traditional names for the best deuce-to-seven
+// hands, indexed by score
+var deuceToSevenNames = []string{"", "Number One", "Number Two", "Number Three", "Number Four"}
+
+// describeLow lists the ranks of the best five cards in their current order,
+// e.g., "7-5-4-3-2"
+func (hr *HandResult) describeLow() string {
+	var ranks = make([]string, len(hr.Best5))
+	for i, c := range hr.Best5 {
+		ranks[i] = c.Rank().String()
+	}
+	return strings.Join(ranks, "-")
+}
@@ -1,6 +1,9 @@
 package poker
 
 import (
+	"errors"
+	"math/rand"
+	"reflect"
 	"testing"
 )
 
@@ -132,6 +135,8 @@ func TestOmahaHands(t *testing.T) {
 		"Flop: high 2": {"2c 7h 4d Jd", "3d Tc 9d", "Jd Tc 9d 7h 3d", "Jack High"},
 		"Turn: Pair":   {"2c 6h 4d Jd", "3d Tc 9d 6s", "6h 6s Jd Tc 9d", "One Pair, Sixes"},
 		"River: Flush": {"2c 7h 4d Jd", "3d Tc 9d 5s Td", "Jd Td 9d 4d 3d", "Jack-High Flush"},
+		"Five hole":    {"As 2d Kc Kh 3s", "7c 8d Ks Qd 5h", "Kc Kh Ks Qd 8d", "Three Of A Kind, Kings"},
+		"Six hole":     {"Kc Kh 9s 9d Ac 6c", "Ks 9c 2h 3c Jc", "Ac Kc Jc 9c 3c", "Ace-High Flush"},
 	}
 
 	// We have so many tests that we're deliberately not checking these ones for
@@ -149,3 +154,162 @@ func TestOmahaHands(t *testing.T) {
 		})
 	}
 }
+
+func TestHandEvaluateHiLo(t *testing.T) {
+	var tests = map[string]struct {
+		hole     string
+		comm     string
+		highDesc string
+		lowBest  string
+		lowDesc  string
+	}{
+		"Scoop with wheel": {"As 2d Kc Kh", "3c 4d 5h Ks Jd", "Five-High Straight", "5h 4d 3c 2d As", "5-4-3-2-A"},
+		"No low":           {"As Kd Qc Jh", "2c 3d 4h Ts 9d", "Ace High", "", ""},
+		"Eight low":        {"8s 7d Kc Kh", "2c 3d 4h Ks Jd", "Three Of A Kind, Kings", "8s 7d 4h 3d 2c", "8-7-4-3-2"},
+		"Flop with low":    {"As 2d 9c 9h", "3c 6d 8h", "One Pair, Nines", "8h 6d 3c 2d As", "8-6-3-2-A"},
+		"Low without pair": {"As 3d 9c 9h", "2c 2d 6h 7s", "Two Pair, Nines And Twos", "7s 6h 3d 2c As", "7-6-3-2-A"},
+		"Big O":            {"As 2d Kc Kh 3s", "7c 8d Ks Qd 5h", "Three Of A Kind, Kings", "8d 7c 5h 2d As", "8-7-5-2-A"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hand, _ = makeHand(tc.hole)
+			var comm, _ = ParseCards(tc.comm)
+			var res, err = hand.EvaluateHiLo(comm...)
+			if err != nil {
+				t.Fatalf("Unable to evaluate %q / %q: %s", tc.hole, tc.comm, err)
+			}
+
+			if res.Describe() != tc.highDesc {
+				t.Errorf("Expected high to be %q, got %q", tc.highDesc, res.Describe())
+			}
+			if tc.lowBest == "" {
+				if res.Low != nil {
+					t.Errorf("Expected no low, got %q (%s)", res.Low.Best5, res.Low.Describe())
+				}
+				return
+			}
+			if res.Low == nil {
+				t.Fatalf("Expected a low of %q, got nil", tc.lowBest)
+			}
+			if res.Low.Best5.String() != tc.lowBest {
+				t.Errorf("Expected low to be %q, got %q", tc.lowBest, res.Low.Best5)
+			}
+			if res.Low.Describe() != tc.lowDesc {
+				t.Errorf("Expected low to be described as %q, got %q", tc.lowDesc, res.Low.Describe())
+			}
+		})
+	}
+}
+
+func TestHandEvaluateHiLoInvalid(t *testing.T) {
+	var hand, _ = makeHand("As 2d")
+	var comm, _ = ParseCards("3c 4d 5h Ks Jd")
+	var _, err = hand.EvaluateHiLo(comm...)
+	if err == nil {
+		t.Fatalf("Expected an error evaluating hold'em hi-lo, got nil")
+	}
+}
+
+func TestHandEvaluateStudHiLo(t *testing.T) {
+	var tests = map[string]struct {
+		hand     string
+		highDesc string
+		lowBest  string
+		lowDesc  string
+	}{
+		"Wheel scoops":      {"Kd Ks As 2c 3d 4h 5h", "Five-High Straight", "5h 4h 3d 2c As", "5-4-3-2-A"},
+		"Kings and a low":   {"Kd Ks 2c 3d 7h 8h Ah", "One Pair, Kings", "8h 7h 3d 2c Ah", "8-7-3-2-A"},
+		"Flush and a low":   {"2h 4h 6h 7h 9h 3c 8d", "Nine-High Flush", "7h 6h 4h 3c 2h", "7-6-4-3-2"},
+		"No qualifying low": {"Kd Ks Qc 2c 3d 7h 9h", "One Pair, Kings", "", ""},
+		"Five cards":        {"8d 7s 6s 5c 4d", "Eight-High Straight", "8d 7s 6s 5c 4d", "8-7-6-5-4"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hand, _ = makeHand(tc.hand)
+			var res, err = hand.EvaluateHiLo()
+			if err != nil {
+				t.Fatalf("Unable to evaluate %q: %s", tc.hand, err)
+			}
+
+			if res.Describe() != tc.highDesc {
+				t.Errorf("Expected high to be %q, got %q", tc.highDesc, res.Describe())
+			}
+			if tc.lowBest == "" {
+				if res.Low != nil {
+					t.Errorf("Expected no low, got %q (%s)", res.Low.Best5, res.Low.Describe())
+				}
+				return
+			}
+			if res.Low == nil {
+				t.Fatalf("Expected a low of %q, got nil", tc.lowBest)
+			}
+			if res.Low.Best5.String() != tc.lowBest {
+				t.Errorf("Expected low to be %q, got %q", tc.lowBest, res.Low.Best5)
+			}
+			if res.Low.Describe() != tc.lowDesc {
+				t.Errorf("Expected low to be described as %q, got %q", tc.lowDesc, res.Low.Describe())
+			}
+		})
+	}
+}
+
+func TestHandReplace(t *testing.T) {
+	var tests = map[string]struct {
+		hand     string
+		discards string
+		err      error
+	}{
+		"Draw two":    {"As Ks 7d 7c 2h", "Ks 2h", nil},
+		"Stand pat":   {"As Ks 7d 7c 2h", "", nil},
+		"Draw five":   {"As Ks 7d 7c 2h", "As Ks 7d 7c 2h", nil},
+		"Not in hand": {"As Ks 7d 7c 2h", "Ks 3h", ErrCardNotInHand},
+		"Same card":   {"As Ks 7d 7c 2h", "Ks Ks", ErrDuplicateCard},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hand, _ = makeHand(tc.hand)
+			var discards, _ = ParseCards(tc.discards)
+			var deck = NewDeck(rand.NewSource(0))
+			deck.Remove(hand.cards.Set())
+
+			var err = hand.Replace(deck, discards...)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected error %v, got %v", tc.err, err)
+			}
+			if err != nil {
+				if hand.String() != tc.hand || len(deck.muck) != 0 {
+					t.Fatalf("Expected a failed draw to change nothing, got %s", hand)
+				}
+				return
+			}
+
+			if len(hand.cards) != 5 || deck.Count() != 47-len(discards) {
+				t.Fatalf("Expected five cards and %d left in the deck, got %s and %d", 47-len(discards), hand, deck.Count())
+			}
+			var got = hand.cards.Set()
+			if got.Intersect(discards.Set()) != 0 {
+				t.Errorf("Expected %s to be gone from %s", discards, hand)
+			}
+			if !reflect.DeepEqual(deck.muck, discards) && len(discards) > 0 {
+				t.Errorf("Expected %s to be mucked, got %s", discards, deck.muck)
+			}
+		})
+	}
+}
+
+func TestHandReplaceEmptyDeck(t *testing.T) {
+	var hand, _ = makeHand("As Ks 7d 7c 2h")
+	var deck = NewDeck(rand.NewSource(0))
+	deck.Draw(51)
+
+	var err = hand.Replace(deck, hand.cards[:2]...)
+	if !errors.Is(err, ErrEmptyDeck) {
+		t.Fatalf("Expected drawing two from a one-card deck to be %q, got %v", ErrEmptyDeck, err)
+	}
+	if hand.String() != "As Ks 7d 7c 2h" || deck.Count() != 1 {
+		t.Fatalf("Expected a failed draw to change nothing, got %s", hand)
+	}
+}
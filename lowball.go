@@ -0,0 +1,241 @@
+package poker
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Ace-to-five lowball scores run from 1 (5-4-3-2-A) to 6175 (four kings with
+// a queen). Hands without a pair come first, and there are exactly 1287 of
+// them.
+const (
+	aceToFiveUnpaired = 1287
+	aceToFiveWorst    = 6175
+)
+
+// binomials holds n-choose-k for every n and k an unpaired five-card low can
+// need
+var binomials = func() (b [13][6]uint16) {
+	for n := range b {
+		b[n][0] = 1
+		for k := 1; k < 6 && k <= n; k++ {
+			b[n][k] = b[n-1][k-1] + b[n-1][k]
+		}
+	}
+	return b
+}()
+
+// aceLowCard returns a copy of the card with its rank shifted up by one so
+// an ace becomes the lowest rank (a deuce) and every other rank moves up one
+// (a king becomes an ace). This lets the high-hand evaluator rank paired
+// hands for lowball.
+func aceLowCard(c Card) Card {
+	return NewCard((c.Rank()+1)%13, c.Suit())
+}
+
+// evalAceToFive scores five cards for ace-to-five lowball, where straights
+// and flushes don't count and aces are always low. The best hand, 5-4-3-2-A,
+// scores 1.
+func evalAceToFive(c1, c2, c3, c4, c5 Card) uint16 {
+	var q = uint32(c1|c2|c3|c4|c5) >> 16
+
+	// Five unique ranks: rotate the ace down to the lowest bit, then rank the
+	// set by its highest card, then its next-highest, and so on. The
+	// combinatorial number system gives us exactly that ordering.
+	if bits.OnesCount32(q) == 5 {
+		var low = (q<<1 | q>>12) & 0x1fff
+		var idx, k uint16
+		for pos := 0; low != 0; pos++ {
+			if low&1 != 0 {
+				k++
+				idx += binomials[pos][k]
+			}
+			low >>= 1
+		}
+		return idx + 1
+	}
+
+	// Paired hands can't be straights or flushes, so with the ace moved to the
+	// bottom, the high-hand scores are simply backwards from what we need.
+	// Flushes and straights leave a gap in high-hand scores between full
+	// houses and trips, which we close up here.
+	var h = evalFiveFast(aceLowCard(c1), aceLowCard(c2), aceLowCard(c3), aceLowCard(c4), aceLowCard(c5))
+	if h > 1609 {
+		return aceToFiveUnpaired + 6186 - h
+	}
+	return 6186 - h
+}
+
+// GetAceToFiveHandRank converts an ace-to-five lowball score into the rank
+// it represents. Since straights and flushes don't exist in this game, the
+// only possible ranks are HighCard, OnePair, TwoPair, ThreeOfAKind, FullHouse,
+// and FourOfAKind.
+func GetAceToFiveHandRank(v uint16) HandRank {
+	if v > 6019 {
+		return FourOfAKind
+	}
+	if v > 5863 {
+		return FullHouse
+	}
+	if v > 5005 {
+		return ThreeOfAKind
+	}
+	if v > 4147 {
+		return TwoPair
+	}
+	if v > aceToFiveUnpaired {
+		return OnePair
+	}
+	return HighCard
+}
+
+// EvaluateAceToFive returns the score for the best five-card ace-to-five low
+// hand, as played in Razz and California lowball. The lower the score, the
+// better the low, with 5-4-3-2-A being 1 and four kings being 6175.
+//
+// Hands can be 5, 6, or 7 cards, otherwise the return will be math.MaxUint16.
+func (cl CardList) EvaluateAceToFive() uint16 {
+	if len(cl) < 5 || len(cl) > 7 {
+		return math.MaxUint16
+	}
+
+	var score, _ = cl.bestHandWith(evalAceToFive)
+	return score
+}
+
+// BestAceToFiveHand returns the same score as EvaluateAceToFive, but also the
+// five cards that made up the best low
+func (cl CardList) BestAceToFiveHand() (score uint16, best [5]Card) {
+	return cl.bestHandWith(evalAceToFive)
+}
+
+// EightOrBetter is the worst ace-to-five score which qualifies for the low
+// half of an eight-or-better split pot: 8-7-6-5-4. Any unpaired hand with no
+// card above an eight scores this or better.
+const EightOrBetter uint16 = 56
+
+// evalEightOrBetter is evalAceToFive, but hands which don't qualify for an
+// eight-or-better low get the worst possible score
+func evalEightOrBetter(c1, c2, c3, c4, c5 Card) uint16 {
+	var score = evalAceToFive(c1, c2, c3, c4, c5)
+	if score > EightOrBetter {
+		return math.MaxUint16
+	}
+	return score
+}
+
+// EvaluateEightOrBetter returns the ace-to-five score for the best
+// eight-or-better low within five to seven cards, as in Seven-Card Stud
+// Hi-Lo. If no qualifying low exists, or there are too few or too many cards,
+// math.MaxUint16 is returned.
+func (cl CardList) EvaluateEightOrBetter() uint16 {
+	var score, _ = cl.BestEightOrBetterHand()
+	return score
+}
+
+// BestEightOrBetterHand returns the same score as EvaluateEightOrBetter, but
+// also the five cards that made up the low
+func (cl CardList) BestEightOrBetterHand() (score uint16, best [5]Card) {
+	return cl.bestHandWith(evalEightOrBetter)
+}
+
+// EvaluateOmahaLow returns the ace-to-five score for the best eight-or-better
+// low that can be made using exactly two of the hole cards and three of the
+// community cards. The hole cards may be four, five, or six cards, as in Omaha
+// Hi-Lo or Big O. If no qualifying low exists, math.MaxUint16 is returned.
+func (cl CardList) EvaluateOmahaLow(community CardList) uint16 {
+	var score, _, _ = cl.BestOmahaLowHand(community)
+	return score
+}
+
+// BestOmahaLowHand returns the same score as EvaluateOmahaLow, as well as the
+// hole and community cards used to make the low
+func (cl CardList) BestOmahaLowHand(community CardList) (score uint16, bestH [2]Card, bestC [3]Card) {
+	return cl.bestOmahaHandWith(community, evalEightOrBetter)
+}
+
+// Deuce-to-seven scores are high-hand scores turned upside down, except the
+// wheel isn't a straight. These are the high-hand scores the wheel has to
+// squeeze in next to: the best king-high hand (K-Q-J-T-8) and the best
+// king-high flush.
+const (
+	kingHighBest      = 6679
+	kingHighFlushBest = 816
+)
+
+// evalDeuceToSeven scores five cards for deuce-to-seven lowball, where aces
+// are always high and straights and flushes count against you. The best
+// hand, 7-5-4-3-2 (unsuited), scores 1, and a royal flush scores 7462.
+func evalDeuceToSeven(c1, c2, c3, c4, c5 Card) uint16 {
+	var h = evalFiveFast(c1, c2, c3, c4, c5)
+
+	// A-5-4-3-2 is just ace-high here, so it slots in right after the best
+	// king-high hand (or flush, if it's suited), and everything worse in high
+	// terms has to shift to make room
+	switch {
+	case h == 1609:
+		return 7464 - kingHighBest
+	case h == 10:
+		return 7464 - kingHighFlushBest
+	case h >= kingHighBest:
+		return 7463 - h
+	case h > 1609:
+		return 7464 - h
+	case h >= kingHighFlushBest:
+		return 7463 - h
+	case h > 10:
+		return 7464 - h
+	}
+	return 7463 - h
+}
+
+// GetDeuceToSevenHandRank converts a deuce-to-seven lowball score into the
+// rank it represents
+func GetDeuceToSevenHandRank(v uint16) HandRank {
+	if v > 7453 {
+		return StraightFlush
+	}
+	if v > 7297 {
+		return FourOfAKind
+	}
+	if v > 7141 {
+		return FullHouse
+	}
+	if v > 5863 {
+		return Flush
+	}
+	if v > 5854 {
+		return Straight
+	}
+	if v > 4996 {
+		return ThreeOfAKind
+	}
+	if v > 4138 {
+		return TwoPair
+	}
+	if v > 1278 {
+		return OnePair
+	}
+	return HighCard
+}
+
+// EvaluateDeuceToSeven returns the score for the best five-card
+// deuce-to-seven low hand, as played in Kansas City lowball and 2-7 triple
+// draw. The lower the score, the better the low, with 7-5-4-3-2 being 1 and a
+// royal flush being 7462.
+//
+// Hands can be 5, 6, or 7 cards, otherwise the return will be math.MaxUint16.
+func (cl CardList) EvaluateDeuceToSeven() uint16 {
+	if len(cl) < 5 || len(cl) > 7 {
+		return math.MaxUint16
+	}
+
+	var score, _ = cl.bestHandWith(evalDeuceToSeven)
+	return score
+}
+
+// BestDeuceToSevenHand returns the same score as EvaluateDeuceToSeven, but
+// also the five cards that made up the best low
+func (cl CardList) BestDeuceToSevenHand() (score uint16, best [5]Card) {
+	return cl.bestHandWith(evalDeuceToSeven)
+}
@@ -0,0 +1,330 @@
+package poker
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEvaluateAceToFive(t *testing.T) {
+	var tests = map[string]struct {
+		hand  string
+		score uint16
+		rank  HandRank
+	}{
+		"The wheel":            {"As 2d 3c 4h 5s", 1, HighCard},
+		"Suited wheel":         {"As 2s 3s 4s 5s", 1, HighCard},
+		"Six-four":             {"6d 4c 3h 2s Ac", 2, HighCard},
+		"Worst eight":          {"8h 7d 6c 5s 4h", 56, HighCard},
+		"Best nine":            {"9h 4d 3c 2s Ah", 57, HighCard},
+		"Worst unpaired":       {"Kh Qd Jc Ts 9h", 1287, HighCard},
+		"Best pair":            {"Ah Ad 2c 3s 4h", 1288, OnePair},
+		"Worst pair":           {"Kh Kd Qc Js Th", 4147, OnePair},
+		"Best two pair":        {"Ah Ad 2c 2s 3h", 4148, TwoPair},
+		"Best trips":           {"Ah Ad Ac 2s 3h", 5006, ThreeOfAKind},
+		"Best full house":      {"Ah Ad Ac 2s 2h", 5864, FullHouse},
+		"Worst four of a kind": {"Kh Kd Kc Ks Qh", 6175, FourOfAKind},
+		"Razz seven cards":     {"Kh Kd 7c 2s 3h 4h 5d", 11, HighCard},
+		"Razz paired board":    {"Kh Kd Qc Qs 2h 2d 2c", 4774, TwoPair},
+		"Four cards":           {"As 2d 3c 4h", math.MaxUint16, HighCard},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cards, err = ParseCards(tc.hand)
+			if err != nil {
+				t.Fatalf("Unable to parse %q: %s", tc.hand, err)
+			}
+
+			var got = cards.EvaluateAceToFive()
+			if got != tc.score {
+				t.Fatalf("%s gave a low score of %d; expected %d", cards, got, tc.score)
+			}
+			if got == math.MaxUint16 {
+				return
+			}
+			var rank = GetAceToFiveHandRank(got)
+			if rank != tc.rank {
+				t.Fatalf("%s gave a low rank of %s; expected %s", cards, rank, tc.rank)
+			}
+		})
+	}
+}
+
+// Every five-card rank combination must have a unique score, and the scores
+// must cover 1 through 6175 without any gaps
+func TestAceToFiveScoresAreDense(t *testing.T) {
+	var suits = [4]CardSuit{Spades, Hearts, Diamonds, Clubs}
+	var seen = make(map[uint16]bool)
+	var ranks [5]CardRank
+	var build func(idx int, from CardRank)
+	build = func(idx int, from CardRank) {
+		if idx == 5 {
+			// Five of a kind doesn't exist
+			if ranks[0] == ranks[4] {
+				return
+			}
+			var cards [5]Card
+			for i, r := range ranks {
+				cards[i] = NewCard(r, suits[i%4])
+			}
+			var score = evalAceToFive(cards[0], cards[1], cards[2], cards[3], cards[4])
+			if seen[score] {
+				t.Fatalf("%s: duplicate score %d", CardList(cards[:]), score)
+			}
+			seen[score] = true
+			return
+		}
+		for r := from; r <= Ace; r++ {
+			ranks[idx] = r
+			build(idx+1, r)
+		}
+	}
+	build(0, Deuce)
+
+	if len(seen) != aceToFiveWorst {
+		t.Fatalf("Got %d distinct scores; expected %d", len(seen), aceToFiveWorst)
+	}
+	for i := uint16(1); i <= aceToFiveWorst; i++ {
+		if !seen[i] {
+			t.Fatalf("No hand had score %d", i)
+		}
+	}
+}
+
+func TestHandEvaluateAceToFive(t *testing.T) {
+	var tests = map[string]struct {
+		hand string
+		comm string
+		best string
+		desc string
+	}{
+		"Razz wheel":         {"Kd 5s 4h Ad 3c 2c Kc", "", "5s 4h 3c 2c Ad", "5-4-3-2-A"},
+		"Razz seven-five":    {"7d Qs 5h Jd 3c 2c Ac", "", "7d 5h 3c 2c Ac", "7-5-3-2-A"},
+		"Razz pair":          {"Kd Ks Qh Qd Jc Jh Tc", "", "Jc Jh Kd Qh Tc", "One Pair, Jacks"},
+		"Draw ignores flush": {"8h 6h 4h 3h 2h", "", "8h 6h 4h 3h 2h", "8-6-4-3-2"},
+		"Hold'em low":        {"Ah 2h", "Kd 8c 7s 3h 3d", "8c 7s 3h 2h Ah", "8-7-3-2-A"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hand, _ = makeHand(tc.hand)
+			var comm, _ = ParseCards(tc.comm)
+			var res, err = hand.EvaluateAs(AceToFiveLow, comm...)
+			if err != nil {
+				t.Fatalf("Unable to evaluate %q / %q: %s", tc.hand, tc.comm, err)
+			}
+			if res.Scoring != AceToFiveLow {
+				t.Errorf("Expected result scoring to be %s, got %s", AceToFiveLow, res.Scoring)
+			}
+			if res.Best5.String() != tc.best {
+				t.Errorf("Expected best hand %q, got %q", tc.best, res.Best5)
+			}
+			if res.Describe() != tc.desc {
+				t.Errorf("Expected description %q, got %q", tc.desc, res.Describe())
+			}
+		})
+	}
+}
+
+func TestEvaluateAsUnknownScoring(t *testing.T) {
+	var hand, _ = makeHand("As 2d 3c 4h 5s")
+	var _, err = hand.EvaluateAs(Scoring(-1))
+	if err == nil {
+		t.Fatalf("Expected an error with unknown scoring, got nil")
+	}
+}
+
+func TestEvaluateDeuceToSeven(t *testing.T) {
+	var tests = map[string]struct {
+		hand  string
+		score uint16
+		rank  HandRank
+	}{
+		"Number one":           {"7s 5d 4c 3h 2s", 1, HighCard},
+		"Number two":           {"7s 6d 4c 3h 2s", 2, HighCard},
+		"Seven-six straight":   {"7s 6d 5c 4h 3s", 5856, Straight},
+		"Seven-high flush":     {"7s 5s 4s 3s 2s", 5864, Flush},
+		"Worst king high":      {"Ks Qd Jc Th 8s", 784, HighCard},
+		"Wheel is ace high":    {"As 5d 4c 3h 2s", 785, HighCard},
+		"Next ace high":        {"As 6d 4c 3h 2s", 786, HighCard},
+		"Best ace high":        {"As Kd Qc Jh 9s", 1278, HighCard},
+		"Best pair":            {"2s 2d 3c 4h 5s", 1279, OnePair},
+		"Worst straight":       {"As Kd Qc Jh Ts", 5863, Straight},
+		"Suited wheel flush":   {"As 5s 4s 3s 2s", 6648, Flush},
+		"Best ace-high flush":  {"As 6s 4s 3s 2s", 6649, Flush},
+		"Royal flush":          {"As Ks Qs Js Ts", 7462, StraightFlush},
+		"Worst four of a kind": {"As Ac Ad Ah Ks", 7453, FourOfAKind},
+		"Six-card hand":        {"7s 5d 4c 3h 2s 2d", 1, HighCard},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cards, err = ParseCards(tc.hand)
+			if err != nil {
+				t.Fatalf("Unable to parse %q: %s", tc.hand, err)
+			}
+
+			var got = cards.EvaluateDeuceToSeven()
+			if got != tc.score {
+				t.Fatalf("%s gave a 2-7 score of %d; expected %d", cards, got, tc.score)
+			}
+			var rank = GetDeuceToSevenHandRank(got)
+			if rank != tc.rank {
+				t.Fatalf("%s gave a 2-7 rank of %s; expected %s", cards, rank, tc.rank)
+			}
+		})
+	}
+}
+
+// Deuce-to-seven scores must use every value from 1 to 7462 exactly once
+func TestDeuceToSevenScoresAreDense(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping exhaustive deuce-to-seven test in short mode")
+	}
+
+	var seen = make(map[uint16]bool)
+	var deck = NewDeck(rand.NewSource(0)).cards
+	for a := 0; a < 52; a++ {
+		for b := a + 1; b < 52; b++ {
+			for c := b + 1; c < 52; c++ {
+				for d := c + 1; d < 52; d++ {
+					for e := d + 1; e < 52; e++ {
+						seen[evalDeuceToSeven(deck[a], deck[b], deck[c], deck[d], deck[e])] = true
+					}
+				}
+			}
+		}
+	}
+
+	if len(seen) != 7462 {
+		t.Fatalf("Got %d distinct scores; expected 7462", len(seen))
+	}
+	for i := uint16(1); i <= 7462; i++ {
+		if !seen[i] {
+			t.Fatalf("No hand had score %d", i)
+		}
+	}
+}
+
+func TestHandEvaluateDeuceToSeven(t *testing.T) {
+	var tests = map[string]struct {
+		hand string
+		best string
+		desc string
+	}{
+		"Number one":   {"2s 4c 7s 3h 5d", "7s 5d 4c 3h 2s", "Number One: 7-5-4-3-2"},
+		"Number four":  {"2s 4c 7s 6h 5d", "7s 6h 5d 4c 2s", "Number Four: 7-6-5-4-2"},
+		"Eight low":    {"2s 4c 8s 6h 3d", "8s 6h 4c 3d 2s", "8-6-4-3-2"},
+		"Wheel":        {"2s 4c As 3h 5d", "As 5d 4c 3h 2s", "A-5-4-3-2"},
+		"Straight":     {"6s 4c 7s 3h 5d", "7s 6s 5d 4c 3h", "Seven-High Straight"},
+		"Flush":        {"2s 4s 7s 3s 5s", "7s 5s 4s 3s 2s", "Seven-High Flush"},
+		"Pair":         {"2s 2c 7s 3h 5d", "2c 2s 7s 5d 3h", "One Pair, Twos"},
+		"Best of six":  {"2s 2c 7s 3h 5d 4d", "7s 5d 4d 3h 2s", "Number One: 7-5-4-3-2"},
+		"Suited wheel": {"2s 4s As 3s 5s", "As 5s 4s 3s 2s", "Ace-High Flush"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hand, _ = makeHand(tc.hand)
+			var res, err = hand.EvaluateAs(DeuceToSevenLow)
+			if err != nil {
+				t.Fatalf("Unable to evaluate %q: %s", tc.hand, err)
+			}
+			if res.Best5.String() != tc.best {
+				t.Errorf("Expected best hand %q, got %q", tc.best, res.Best5)
+			}
+			if res.Describe() != tc.desc {
+				t.Errorf("Expected description %q, got %q", tc.desc, res.Describe())
+			}
+		})
+	}
+}
+
+func TestDeuceToSevenTies(t *testing.T) {
+	var a, _ = makeHand("7s 5d 4c 3h 2s")
+	var b, _ = makeHand("7d 5c 4h 3s 2d")
+	var c, _ = makeHand("7d 6c 4h 3s 2d")
+	var resA, _ = a.EvaluateAs(DeuceToSevenLow)
+	var resB, _ = b.EvaluateAs(DeuceToSevenLow)
+	var resC, _ = c.EvaluateAs(DeuceToSevenLow)
+
+	if resA.Score != resB.Score {
+		t.Errorf("Expected %s and %s to tie, but got scores %d and %d", a, b, resA.Score, resB.Score)
+	}
+	if resA.Score >= resC.Score {
+		t.Errorf("Expected %s to beat %s, but got scores %d and %d", a, c, resA.Score, resC.Score)
+	}
+}
+
+func TestBestOmahaLowHand(t *testing.T) {
+	var tests = map[string]struct {
+		hole     string
+		comm     string
+		score    uint16
+		bestHole string
+		bestComm string
+	}{
+		"Nut low":            {"As 2d Kc Kh", "3c 4d 5h Qs Jd", 1, "As 2d", "3c 4d 5h"},
+		"Must use two hole":  {"As Kd Kc Kh", "2c 3d 4h 5s 6d", math.MaxUint16, "", ""},
+		"Counterfeited":      {"As 2d Kc Kh", "Ac 2c 7h 8s Td", math.MaxUint16, "", ""},
+		"Counterfeit backup": {"As 2d 3c Kh", "Ac 2c 7h 8s Td", 37, "As 3c", "2c 7h 8s"},
+		"Only two low cards": {"As 2d 3c 4h", "7c 8d Ks Qd Jh", math.MaxUint16, "", ""},
+		"Flop low":           {"As 2d 9c 9h", "3c 6d 8h", 27, "As 2d", "3c 6d 8h"},
+		"Pair on board":      {"As 3d 9c 9h", "2c 2d 6h 7s", 12, "As 3d", "2c 6h 7s"},
+		"Big O":              {"As 2d Kc Kh 3s", "7c 8d Ks Qd 5h", 41, "As 2d", "7c 8d 5h"},
+		"Three hole cards":   {"As 2d 3s", "7c 8d Ks Qd 5h", math.MaxUint16, "", ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hole, _ = ParseCards(tc.hole)
+			var comm, _ = ParseCards(tc.comm)
+			var score, bestH, bestC = hole.BestOmahaLowHand(comm)
+			if score != tc.score {
+				t.Fatalf("%s / %s gave a low of %d; expected %d", hole, comm, score, tc.score)
+			}
+			if hole.EvaluateOmahaLow(comm) != score {
+				t.Fatalf("EvaluateOmahaLow and BestOmahaLowHand don't agree for %s / %s", hole, comm)
+			}
+			if score == math.MaxUint16 {
+				return
+			}
+			if CardList(bestH[:]).String() != tc.bestHole || CardList(bestC[:]).String() != tc.bestComm {
+				t.Fatalf("%s / %s: expected %s,%s to be the best low, got %s,%s", hole, comm,
+					tc.bestHole, tc.bestComm, CardList(bestH[:]), CardList(bestC[:]))
+			}
+		})
+	}
+}
+
+func TestBestEightOrBetterHand(t *testing.T) {
+	var tests = map[string]struct {
+		hand  string
+		score uint16
+		best  string
+	}{
+		"Stud wheel":       {"Kd Ks As 2c 3d 4h 5h", 1, "As 2c 3d 4h 5h"},
+		"Stud eight":       {"8d Ks As 2c 3d Kh 5h", 23, "8d As 2c 3d 5h"},
+		"Stud no low":      {"9d Ks As 2c 3d Kh 5h", math.MaxUint16, ""},
+		"Paired low cards": {"2d 2s As Ac 3d 3h 5h", math.MaxUint16, ""},
+		"Five-card low":    {"8d 7s 6s 5c 4d", EightOrBetter, "8d 7s 6s 5c 4d"},
+		"Too few cards":    {"As 2c 3d 4h", math.MaxUint16, ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cards, _ = ParseCards(tc.hand)
+			var score, best = cards.BestEightOrBetterHand()
+			if score != tc.score {
+				t.Fatalf("%s gave a low of %d; expected %d", cards, score, tc.score)
+			}
+			if cards.EvaluateEightOrBetter() != score {
+				t.Fatalf("EvaluateEightOrBetter and BestEightOrBetterHand don't agree for %s", cards)
+			}
+			if score != math.MaxUint16 && CardList(best[:]).String() != tc.best {
+				t.Fatalf("%s: expected %s to be the best low, got %s", cards, tc.best, CardList(best[:]))
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package poker
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// NutHand is one of the best possible hands on a board. Result describes the
+// hand as made by the first of its combos, and Combos lists every pair of
+// hole cards which makes a hand with exactly the same score.
+type NutHand struct {
+	Result *HandResult
+	Combos []CardList
+}
+
+// Nuts returns the best possible Texas Hold 'em hand on a three- to five-card
+// board, e.g., so a dealer display can say "the nuts is a Flush, King High"
+func Nuts(board CardList) (*NutHand, error) {
+	var hands, err = topHands(board, 1, false)
+	if err != nil {
+		return nil, err
+	}
+	return hands[0], nil
+}
+
+// TopHands returns the n best possible Texas Hold 'em hands on the board,
+// best first. Hands are distinct by score, so all the hole cards which make
+// the same straight are one entry. Fewer than n are returned if the board
+// doesn't allow that many different hands.
+func TopHands(board CardList, n int) ([]*NutHand, error) {
+	return topHands(board, n, false)
+}
+
+// OmahaNuts is Nuts for Omaha, where the hand must use exactly two hole
+// cards. Since the other hole cards can't play, each combo is just the two
+// which matter.
+func OmahaNuts(board CardList) (*NutHand, error) {
+	var hands, err = topHands(board, 1, true)
+	if err != nil {
+		return nil, err
+	}
+	return hands[0], nil
+}
+
+// OmahaTopHands is TopHands for Omaha's two-from-hand rule
+func OmahaTopHands(board CardList, n int) ([]*NutHand, error) {
+	return topHands(board, n, true)
+}
+
+// scoredCombo is a pair of hole cards and the best hand they make
+type scoredCombo struct {
+	hole  CardList
+	score uint16
+	best  [5]Card
+}
+
+func topHands(board CardList, n int, omaha bool) ([]*NutHand, error) {
+	if len(board) < 3 || len(board) > 5 {
+		return nil, fmt.Errorf("%w: board must be three to five cards", ErrInvalidCardCount)
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("%w: must ask for at least one hand", ErrInvalidCardCount)
+	}
+	var unseen, err = remainingCards(board)
+	if err != nil {
+		return nil, err
+	}
+
+	var combos = make([]scoredCombo, 0, len(unseen)*(len(unseen)-1)/2)
+	var cards = append(make(CardList, 0, 7), board...)
+	cards = cards[:len(cards)+2]
+	for i, c1 := range unseen {
+		for _, c2 := range unseen[i+1:] {
+			var sc = scoredCombo{hole: CardList{c1, c2}}
+			if omaha {
+				var bestH [2]Card
+				var bestC [3]Card
+				sc.score, bestH, bestC = bestOmahaPair(sc.hole, board)
+				sc.best = [5]Card{bestH[0], bestH[1], bestC[0], bestC[1], bestC[2]}
+			} else {
+				cards[len(cards)-2], cards[len(cards)-1] = c1, c2
+				sc.score, sc.best = cards.BestHand()
+			}
+			combos = append(combos, sc)
+		}
+	}
+
+	// A stable sort keeps each hand's combos in deck order
+	sort.SliceStable(combos, func(i, j int) bool { return combos[i].score < combos[j].score })
+
+	var hands []*NutHand
+	for _, sc := range combos {
+		var last = len(hands) - 1
+		if last >= 0 && hands[last].Result.Score == sc.score {
+			hands[last].Combos = append(hands[last].Combos, sc.hole)
+			continue
+		}
+		if len(hands) == n {
+			break
+		}
+
+		var hr = &HandResult{
+			Hand:      sc.hole,
+			Community: append(CardList(nil), board...),
+			Scoring:   HighScoring,
+			Score:     sc.score,
+			best:      sc.best,
+		}
+		hr.finish()
+		hands = append(hands, &NutHand{Result: hr, Combos: []CardList{sc.hole}})
+	}
+
+	return hands, nil
+}
+
+// bestOmahaPair is BestOmahaHand for exactly two hole cards, which is all that
+// matters when asking what hands are possible
+func bestOmahaPair(hole, board CardList) (score uint16, bestH [2]Card, bestC [3]Card) {
+	score = math.MaxUint16
+	bestH = [2]Card{hole[0], hole[1]}
+	var cPerms = omahaCommunityPerms
+	switch len(board) {
+	case 3:
+		cPerms = omahaCommunityPerms[:1]
+	case 4:
+		cPerms = omahaCommunityPerms[:4]
+	}
+
+	for _, commP := range cPerms {
+		var val = evalFiveFast(hole[0], hole[1], board[commP[0]], board[commP[1]], board[commP[2]])
+		if val < score {
+			score = val
+			bestC = [3]Card{board[commP[0]], board[commP[1]], board[commP[2]]}
+		}
+	}
+	return
+}
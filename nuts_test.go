@@ -0,0 +1,140 @@
+package poker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNuts(t *testing.T) {
+	var tests = map[string]struct {
+		board    string
+		omaha    bool
+		describe string
+		combos   int
+		first    string
+	}{
+		"Flush board":          {"Ah 9h 4h 2c 7d", false, "Ace-High Flush", 1, "Qh Kh"},
+		"Omaha flush board":    {"Ah 9h 4h 2c 7d", true, "Ace-High Flush", 1, "Qh Kh"},
+		"One card plays":       {"As Ks Qs Js 2d", false, "Royal Flush", 46, "2s Ts"},
+		"Omaha needs two":      {"As Ks Qs Js 2d", true, "King-High Straight Flush", 1, "9s Ts"},
+		"Dry flop":             {"Kd 7c 2s", false, "Three Of A Kind, Kings", 3, "Ks Kh"},
+		"Paired turn":          {"Kd Kc 7h 2s", false, "Four Of A Kind, Kings", 1, "Ks Kh"},
+		"Omaha paired turn":    {"Kd Kc 7h 2s", true, "Four Of A Kind, Kings", 1, "Ks Kh"},
+		"Straight on the flop": {"9c Td Jh", false, "King-High Straight", 16, "Qs Ks"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var board, _ = ParseCards(tc.board)
+			var nuts *NutHand
+			var err error
+			if tc.omaha {
+				nuts, err = OmahaNuts(board)
+			} else {
+				nuts, err = Nuts(board)
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if nuts.Result.Describe() != tc.describe {
+				t.Errorf("Expected the nuts on %s to be %q, got %q", board, tc.describe, nuts.Result.Describe())
+			}
+			if len(nuts.Combos) != tc.combos {
+				t.Errorf("Expected %d combos, got %d: %v", tc.combos, len(nuts.Combos), nuts.Combos)
+			}
+			if nuts.Combos[0].String() != tc.first {
+				t.Errorf("Expected the first combo to be %q, got %q", tc.first, nuts.Combos[0])
+			}
+		})
+	}
+}
+
+func TestTopHands(t *testing.T) {
+	var board, _ = ParseCards("As Ks Qs Js 2d")
+	var hands, err = TopHands(board, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var expected = []struct {
+		best   string
+		combos int
+	}{
+		{"As Ks Qs Js Ts", 46},
+		{"As Ks Qs Js 9s", 45},
+		{"As Ks Qs Js 8s", 44},
+	}
+	if len(hands) != len(expected) {
+		t.Fatalf("Expected %d hands, got %d", len(expected), len(hands))
+	}
+	for i, e := range expected {
+		if hands[i].Result.Best5.String() != e.best || len(hands[i].Combos) != e.combos {
+			t.Errorf("Expected hand %d to be %s with %d combos, got %s with %d",
+				i, e.best, e.combos, hands[i].Result.Best5, len(hands[i].Combos))
+		}
+		if i > 0 && hands[i].Result.Score <= hands[i-1].Result.Score {
+			t.Errorf("Expected hand %d to be worse than hand %d", i, i-1)
+		}
+	}
+
+	// Omaha can't use the one-card royal, or any one-spade flush
+	hands, _ = OmahaTopHands(board, 2)
+	if hands[0].Result.Best5.String() != "Ks Qs Js Ts 9s" || hands[1].Result.Best5.String() != "As Ks Qs Ts 8s" {
+		t.Errorf("Expected Omaha's top two to be a king-high straight flush and AKQT8, got %s and %s",
+			hands[0].Result.Best5, hands[1].Result.Best5)
+	}
+}
+
+func TestTopHandsCoverEveryCombo(t *testing.T) {
+	// Asking for more hands than exist returns them all, and every two-card
+	// combo must show up exactly once
+	var board, _ = ParseCards("Ah Kd 7c 7s 2h")
+	var hands, _ = TopHands(board, 10000)
+	var seen = make(map[CardSet]bool)
+	for _, h := range hands {
+		for _, c := range h.Combos {
+			var set = c.Set()
+			if seen[set] {
+				t.Fatalf("Combo %s was listed twice", c)
+			}
+			seen[set] = true
+		}
+	}
+	if len(seen) != 47*46/2 {
+		t.Errorf("Expected %d combos, got %d", 47*46/2, len(seen))
+	}
+}
+
+func TestNutsErrors(t *testing.T) {
+	var tests = map[string]struct {
+		board string
+		n     int
+		err   error
+	}{
+		"Preflop":    {"", 1, ErrInvalidCardCount},
+		"Six cards":  {"Ah Kh Qh Jh Th 9h", 1, ErrInvalidCardCount},
+		"Duplicate":  {"Ah Kh Ah", 1, ErrDuplicateCard},
+		"Zero hands": {"Ah Kh Qh", 0, ErrInvalidCardCount},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var board, _ = ParseCards(tc.board)
+			var _, err = TopHands(board, tc.n)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %q, got %v", tc.err, err)
+			}
+			_, err = OmahaTopHands(board, tc.n)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Omaha: expected %q, got %v", tc.err, err)
+			}
+		})
+	}
+
+	var kh, _ = NewCardString("Kh")
+	var qh, _ = NewCardString("Qh")
+	var _, err = Nuts(CardList{0, kh, qh})
+	if !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("Expected a zero card to be %q, got %v", ErrInvalidCard, err)
+	}
+}
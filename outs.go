@@ -0,0 +1,275 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DrawKind names a type of drawing hand
+type DrawKind int
+
+// All draws we can detect. Double gutshots have the same eight outs as an
+// open-ended straight draw, so they're reported as one.
+const (
+	FlushDraw DrawKind = iota + 1
+	OpenEndedStraightDraw
+	GutshotStraightDraw
+)
+
+func (k DrawKind) String() string {
+	switch k {
+	case FlushDraw:
+		return "Flush Draw"
+	case OpenEndedStraightDraw:
+		return "Open-Ended Straight Draw"
+	case GutshotStraightDraw:
+		return "Gutshot Straight Draw"
+	}
+
+	return ""
+}
+
+// Draw is a single drawing hand along with the cards which complete it
+type Draw struct {
+	Kind DrawKind
+	Outs CardList
+}
+
+// Outs describes every unseen card which would improve a hand if it came next
+type Outs struct {
+	// Rank is the hand's rank before the next card
+	Rank HandRank
+
+	// Cards holds every out, in deck order
+	Cards CardList
+
+	// ByRank groups the outs by the rank they would make
+	ByRank map[HandRank]CardList
+
+	// Draws lists the straight and flush draws the hole cards are part of
+	Draws []Draw
+}
+
+// FindOuts looks at every card which hasn't been seen yet, and reports which
+// of them would improve two hole cards and a three- or four-card board to a
+// better HandRank. The hole cards have to play: a card only counts if it
+// pairs one of them, or makes a straight or better which beats what the board
+// makes on its own. Pairing the board or filling a straight on it isn't an
+// out, even when it gives the hand a better rank. Straight and flush draws are named separately, but only when
+// the hole cards are part of them: four hearts on the board isn't your flush
+// draw.
+func FindOuts(hole, board CardList) (*Outs, error) {
+	return findOuts(hole, board, nil)
+}
+
+// FindOutsAgainst is like FindOuts, but instead of looking for cards that
+// improve the hand's rank, it looks for cards which would make the hand beat
+// the opponent's two hole cards. The opponent's cards are never counted as
+// outs, and draws whose outs wouldn't win are left out.
+func FindOutsAgainst(hole, board, opponent CardList) (*Outs, error) {
+	if len(opponent) != 2 {
+		return nil, fmt.Errorf("%w: opponent needs exactly two hole cards", ErrInvalidCardCount)
+	}
+	return findOuts(hole, board, opponent)
+}
+
+func findOuts(hole, board, opponent CardList) (*Outs, error) {
+	if len(hole) != 2 {
+		return nil, fmt.Errorf("%w: need exactly two hole cards", ErrInvalidCardCount)
+	}
+	if len(board) != 3 && len(board) != 4 {
+		return nil, fmt.Errorf("%w: board must be three or four cards", ErrInvalidCardCount)
+	}
+
+	var known = append(append(append(CardList(nil), hole...), board...), opponent...)
+	var unseen, err = remainingCards(known)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each list has room for the next card at the end
+	var cards = append(append(make(CardList, 0, 7), hole...), board...)
+	var oppCards = append(append(make(CardList, 0, 7), opponent...), board...)
+	var boardCards = append(make(CardList, 0, 5), board...)
+	var o = &Outs{
+		Rank:   GetHandRank(cards.Evaluate()),
+		ByRank: make(map[HandRank]CardList),
+	}
+	cards = cards[:len(cards)+1]
+	oppCards = oppCards[:len(oppCards)+1]
+	boardCards = boardCards[:len(boardCards)+1]
+
+	for _, c := range unseen {
+		cards[len(cards)-1] = c
+		var score = cards.Evaluate()
+		var rank = GetHandRank(score)
+
+		boardCards[len(boardCards)-1] = c
+		var playsHole = c.Rank() == hole[0].Rank() || c.Rank() == hole[1].Rank() || rank <= Straight
+		var out = rank < o.Rank && playsHole && beatsBoard(score, boardCards)
+		if opponent != nil {
+			oppCards[len(oppCards)-1] = c
+			out = score < oppCards.Evaluate()
+		}
+		if out {
+			o.Cards = append(o.Cards, c)
+			o.ByRank[rank] = append(o.ByRank[rank], c)
+		}
+	}
+
+	var outSet = o.Cards.Set()
+	for _, d := range findDraws(hole, board, o.Rank, unseen) {
+		var live CardList
+		for _, c := range d.Outs {
+			if outSet.Contains(c) {
+				live = append(live, c)
+			}
+		}
+		if len(live) > 0 {
+			o.Draws = append(o.Draws, Draw{Kind: d.Kind, Outs: live})
+		}
+	}
+
+	return o, nil
+}
+
+// beatsBoard returns true if a hand is a better rank than the board makes by
+// itself, or a better straight, flush, full house, or straight flush. Those
+// have no kickers, so beating the board with the same rank means the hole
+// cards play; a better kicker alone doesn't count. Four cards can't make a
+// straight or flush, so only their pairs and sets count.
+func beatsBoard(score uint16, board CardList) bool {
+	var rank = GetHandRank(score)
+	if len(board) == 5 {
+		var boardScore = board.Evaluate()
+		var boardRank = GetHandRank(boardScore)
+		return rank < boardRank || rank == boardRank && rank <= Straight && rank != FourOfAKind && score < boardScore
+	}
+
+	var boardRank = HighCard
+	var showing = showingValue(board)
+	switch {
+	case showing[0] == 4:
+		boardRank = FourOfAKind
+	case showing[0] == 3:
+		boardRank = ThreeOfAKind
+	case showing[1] == 1:
+		boardRank = TwoPair
+	case showing[0] == 2:
+		boardRank = OnePair
+	}
+	return rank < boardRank
+}
+
+// findDraws returns the flush and straight draws the hole cards are part of,
+// with every unseen card which would complete each one
+func findDraws(hole, board CardList, rank HandRank, unseen CardList) []Draw {
+	var draws []Draw
+
+	if rank > Flush {
+		for _, suit := range allSuits {
+			if countSuit(board, suit) == 4 || countSuit(hole, suit)+countSuit(board, suit) != 4 {
+				continue
+			}
+			var d = Draw{Kind: FlushDraw}
+			for _, c := range unseen {
+				if c.Suit() == suit {
+					d.Outs = append(d.Outs, c)
+				}
+			}
+			draws = append(draws, d)
+		}
+	}
+
+	if rank > Straight {
+		var boardMask = rankMask(board)
+		var mask = boardMask | rankMask(hole)
+		var completing uint32
+		for r := Deuce; r <= Ace; r++ {
+			var bit = uint32(1) << r
+			if mask&bit == 0 && hasStraight(mask|bit) && !hasStraight(boardMask|bit) {
+				completing |= bit
+			}
+		}
+
+		var d Draw
+		switch {
+		case completing == 0:
+		case completing&(completing-1) == 0:
+			d.Kind = GutshotStraightDraw
+		default:
+			d.Kind = OpenEndedStraightDraw
+		}
+		if d.Kind != 0 {
+			for _, c := range unseen {
+				if completing&(1<<c.Rank()) != 0 {
+					d.Outs = append(d.Outs, c)
+				}
+			}
+			draws = append(draws, d)
+		}
+	}
+
+	return draws
+}
+
+func countSuit(cards CardList, suit CardSuit) int {
+	var n int
+	for _, c := range cards {
+		if c.Suit() == suit {
+			n++
+		}
+	}
+	return n
+}
+
+// rankMask returns a bit for each rank in the cards, using the same rank bits
+// Card stores in its upper half
+func rankMask(cards CardList) uint32 {
+	var mask uint32
+	for _, c := range cards {
+		mask |= uint32(c) >> 16
+	}
+	return mask
+}
+
+// hasStraight returns true if the rank bits have five in a row, counting an
+// ace as low, too
+func hasStraight(mask uint32) bool {
+	var m = mask<<1 | mask>>12&1
+	return m&(m>>1)&(m>>2)&(m>>3)&(m>>4) != 0
+}
+
+// DrawOuts returns every card which completes one of the named draws, in deck
+// order. It's usually smaller than Cards, which also counts cards that only
+// pair up the hand.
+func (o *Outs) DrawOuts() CardList {
+	var set CardSet
+	for _, d := range o.Draws {
+		set = set.Union(d.Outs.Set())
+	}
+	return set.Cards()
+}
+
+// Describe returns a human-friendly summary of the draws and outs, e.g.,
+// "Flush Draw and Open-Ended Straight Draw, 15 outs". When there are named
+// draws, only their outs are counted.
+func (o *Outs) Describe() string {
+	var names []string
+	for _, d := range o.Draws {
+		names = append(names, d.Kind.String())
+	}
+
+	var n = len(o.Cards)
+	if len(names) > 0 {
+		n = len(o.DrawOuts())
+	}
+	var count = fmt.Sprintf("%d outs", n)
+	if n == 1 {
+		count = "1 out"
+	}
+	if len(names) == 0 {
+		return count
+	}
+	return strings.Join(names, " and ") + ", " + count
+}
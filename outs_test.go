@@ -0,0 +1,154 @@
+package poker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindOuts(t *testing.T) {
+	var tests = map[string]struct {
+		hole     string
+		board    string
+		describe string
+		draws    []DrawKind
+	}{
+		"Combo draw":           {"9h 8h", "7h 6c 2h", "Flush Draw and Open-Ended Straight Draw, 15 outs", []DrawKind{FlushDraw, OpenEndedStraightDraw}},
+		"Gutshot":              {"9c 8d", "Jh 7s 2c", "Gutshot Straight Draw, 4 outs", []DrawKind{GutshotStraightDraw}},
+		"Double gutshot":       {"9c 7d", "Jh 5s 8c", "Open-Ended Straight Draw, 8 outs", []DrawKind{OpenEndedStraightDraw}},
+		"Wheel draw":           {"Ac 2d", "3h 4s Kc", "Gutshot Straight Draw, 4 outs", []DrawKind{GutshotStraightDraw}},
+		"Turn flush draw":      {"Ah 3h", "Kh 9h 2c 7d", "Flush Draw, 9 outs", []DrawKind{FlushDraw}},
+		"Board's flush draw":   {"Ac Kd", "2h 5h 9h Jh", "4 outs", nil},
+		"Board's straight":     {"Ac Kd", "5h 6s 7c 8d", "6 outs", nil},
+		"Higher straight":      {"Ac Td", "5h 6s 7c 8d", "10 outs", nil},
+		"Made straight":        {"9h 8h", "7h 6c 5s", "0 outs", nil},
+		"Straight, flush draw": {"9h 8h", "7h 6h 5s", "Flush Draw, 9 outs", []DrawKind{FlushDraw}},
+		"Made hand, no draws":  {"As Ad", "Kh 7c 2d", "2 outs", nil},
+		"Set to a full house":  {"7s 7d", "Kh 7c 2d", "7 outs", nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hole, _ = ParseCards(tc.hole)
+			var board, _ = ParseCards(tc.board)
+			var o, err = FindOuts(hole, board)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if o.Describe() != tc.describe {
+				t.Errorf("Expected %s / %s to be %q, got %q", hole, board, tc.describe, o.Describe())
+			}
+			if len(o.Draws) != len(tc.draws) {
+				t.Fatalf("Expected draws %v, got %v", tc.draws, o.Draws)
+			}
+			for i, d := range o.Draws {
+				if d.Kind != tc.draws[i] {
+					t.Errorf("Expected draw %d to be %s, got %s", i, tc.draws[i], d.Kind)
+				}
+			}
+		})
+	}
+}
+
+func TestFindOutsByRank(t *testing.T) {
+	var hole, _ = ParseCards("9h 8h")
+	var board, _ = ParseCards("7h 6c 2h")
+	var o, _ = FindOuts(hole, board)
+
+	if o.Rank != HighCard {
+		t.Errorf("Expected the hand to be %s, got %s", HighCard, o.Rank)
+	}
+
+	// Pairing the board doesn't count
+	var expected = map[HandRank]int{Flush: 9, Straight: 6, OnePair: 6}
+	var total int
+	for rank, n := range expected {
+		if len(o.ByRank[rank]) != n {
+			t.Errorf("Expected %d outs to make %s, got %s", n, rank, o.ByRank[rank])
+		}
+		total += n
+	}
+	if len(o.ByRank) != len(expected) {
+		t.Errorf("Expected outs for %d ranks, got %v", len(expected), o.ByRank)
+	}
+	if len(o.Cards) != total {
+		t.Errorf("Expected %d outs, got %d", total, len(o.Cards))
+	}
+
+	// Improving to a flush and a straight at once only counts once
+	var th, _ = NewCardString("Th")
+	if len(o.DrawOuts()) != 15 || !o.DrawOuts().Set().Contains(th) {
+		t.Errorf("Expected 15 draw outs including Th, got %s", o.DrawOuts())
+	}
+}
+
+func TestFindOutsAgainst(t *testing.T) {
+	var hole, _ = ParseCards("Ah Kh")
+	var board, _ = ParseCards("Qh 7h 2d")
+	var opp, _ = ParseCards("Qs Qc")
+	var o, err = FindOutsAgainst(hole, board, opp)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// The 7h and 2h fill up the set
+	var expected = "3h 4h 5h 6h 8h 9h Th Jh"
+	if o.Cards.String() != expected {
+		t.Errorf("Expected outs %q, got %q", expected, o.Cards)
+	}
+	if len(o.Draws) != 1 || o.Draws[0].Kind != FlushDraw || len(o.Draws[0].Outs) != 8 {
+		t.Errorf("Expected a flush draw with 8 live outs, got %v", o.Draws)
+	}
+	if o.Describe() != "Flush Draw, 8 outs" {
+		t.Errorf("Expected %q, got %q", "Flush Draw, 8 outs", o.Describe())
+	}
+
+	// A straight draw against a made flush has no live outs at all
+	hole, _ = ParseCards("9c 8d")
+	board, _ = ParseCards("Jh 7h 2h")
+	opp, _ = ParseCards("Ah 3h")
+	o, _ = FindOutsAgainst(hole, board, opp)
+	if len(o.Cards) != 0 || len(o.Draws) != 0 {
+		t.Errorf("Expected no outs against a flush, got %s, %v", o.Cards, o.Draws)
+	}
+}
+
+func TestFindOutsErrors(t *testing.T) {
+	var tests = map[string]struct {
+		hole  string
+		board string
+		opp   string
+		err   error
+	}{
+		"Three hole cards": {"Ah Kh Qh", "2c 3c 4c", "", ErrInvalidCardCount},
+		"Preflop":          {"Ah Kh", "", "", ErrInvalidCardCount},
+		"River":            {"Ah Kh", "2c 3c 4c 5c 6c", "", ErrInvalidCardCount},
+		"Duplicate":        {"Ah Kh", "2c 3c Ah", "", ErrDuplicateCard},
+		"Opponent overlap": {"Ah Kh", "2c 3c 4c", "Kh Qd", ErrDuplicateCard},
+		"One-card opp":     {"Ah Kh", "2c 3c 4c", "Qd", ErrInvalidCardCount},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hole, _ = ParseCards(tc.hole)
+			var board, _ = ParseCards(tc.board)
+			var err error
+			if tc.opp == "" {
+				_, err = FindOuts(hole, board)
+			} else {
+				var opp, _ = ParseCards(tc.opp)
+				_, err = FindOutsAgainst(hole, board, opp)
+			}
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected %q, got %v", tc.err, err)
+			}
+		})
+	}
+
+	var hole, _ = ParseCards("Ah Kh")
+	var board, _ = ParseCards("2c 3c 4c")
+	hole[1] = 0
+	var _, err = FindOuts(hole, board)
+	if !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("Expected a zero hole card to be %q, got %v", ErrInvalidCard, err)
+	}
+}
@@ -0,0 +1,220 @@
+package poker
+
+import (
+	"sort"
+)
+
+// Contribution is everything one player put into the pot during a hand, and
+// whether they folded. Players who were never dealt in can be left as the
+// zero value, which contributes nothing and wins nothing.
+type Contribution struct {
+	Amount int
+	Folded bool
+}
+
+// Pot is the main pot or a side pot: some chips, and the players who are
+// eligible to win them, by their index in the contributions
+type Pot struct {
+	Amount   int
+	Eligible []int
+}
+
+// BuildPots splits the contributions into a main pot and any side pots. Each
+// player who didn't fold is eligible for every pot up to what they put in, so
+// a player who's all in for less can't win more from each opponent than they
+// risked. Folded players' chips still count, but they aren't eligible for
+// anything. Pots are returned main pot first.
+//
+// Chips nobody else matched end up in a final pot with one eligible player,
+// which simply gives them back. If no live player put in any chips, the
+// folded chips go in a single pot for everybody who didn't fold. The pots
+// always add up to every contribution, so when everybody folded, that pot has
+// no eligible players and it's up to the caller to decide who gets it.
+func BuildPots(contribs []Contribution) []Pot {
+	var levels []int
+	for _, c := range contribs {
+		if !c.Folded && c.Amount > 0 {
+			levels = append(levels, c.Amount)
+		}
+	}
+	sort.Ints(levels)
+
+	var pots []Pot
+	var prev int
+	for _, level := range levels {
+		if level == prev {
+			continue
+		}
+
+		var pot Pot
+		for i, c := range contribs {
+			pot.Amount += clamp(c.Amount, prev, level) - prev
+			if !c.Folded && c.Amount >= level {
+				pot.Eligible = append(pot.Eligible, i)
+			}
+		}
+		pots = append(pots, pot)
+		prev = level
+	}
+
+	// Folded chips above every live player's contribution can't be won by
+	// anybody else, so they go to whoever is left in the last pot
+	if len(pots) == 0 {
+		var pot Pot
+		for i, c := range contribs {
+			pot.Amount += c.Amount
+			if !c.Folded {
+				pot.Eligible = append(pot.Eligible, i)
+			}
+		}
+		if pot.Amount > 0 {
+			pots = append(pots, pot)
+		}
+		return pots
+	}
+	for _, c := range contribs {
+		if c.Amount > prev {
+			pots[len(pots)-1].Amount += c.Amount - prev
+		}
+	}
+
+	return pots
+}
+
+// clamp returns n limited to the range lo to hi
+func clamp(n, lo, hi int) int {
+	if n < lo {
+		return lo
+	}
+	if n > hi {
+		return hi
+	}
+	return n
+}
+
+// OddChipRule puts tied winners in the order they should receive odd chips
+// when a pot can't be split evenly. results are indexed the same way as the
+// contributions the pots were built from. Wherever a rule is taken, nil hands
+// out odd chips in index order.
+type OddChipRule func(winners []int, results []*HandResult) []int
+
+// OddChipLeftOfButton gives odd chips to the winners closest to the button's
+// left, the usual rule for flop games
+func OddChipLeftOfButton(button, numPlayers int) OddChipRule {
+	return func(winners []int, results []*HandResult) []int {
+		var ordered = append([]int(nil), winners...)
+		var distance = func(i int) int { return (i - button - 1 + numPlayers) % numPlayers }
+		sort.SliceStable(ordered, func(a, b int) bool { return distance(ordered[a]) < distance(ordered[b]) })
+		return ordered
+	}
+}
+
+// OddChipBySuit gives odd chips to the winner holding the highest card in
+// their best hand, with suits breaking ties between cards of the same rank:
+// spades, then hearts, diamonds, and clubs. If two winners' highest cards are
+// the same, as when a board card plays, their next-highest cards are
+// compared, and so on. This is the usual rule for stud games, where there's
+// no button.
+func OddChipBySuit(winners []int, results []*HandResult) []int {
+	var ordered = append([]int(nil), winners...)
+	var cards = make(map[int][]int)
+	for _, i := range winners {
+		var precedence []int
+		if results[i] != nil {
+			for _, c := range results[i].Best5 {
+				precedence = append(precedence, c.precedence())
+			}
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(precedence)))
+		cards[i] = precedence
+	}
+
+	sort.SliceStable(ordered, func(a, b int) bool {
+		var ca, cb = cards[ordered[a]], cards[ordered[b]]
+		for n := 0; n < len(ca) && n < len(cb); n++ {
+			if ca[n] != cb[n] {
+				return ca[n] > cb[n]
+			}
+		}
+		return len(ca) > len(cb)
+	})
+	return ordered
+}
+
+// Distribute awards each pot to the best eligible hands in results and
+// returns how many chips each player wins. A nil result means the player has
+// no hand to show, such as when they folded. A pot nobody eligible has a
+// result for, like an uncontested pot, is split among its eligible players.
+func Distribute(pots []Pot, results []*HandResult, oddChip OddChipRule) []int {
+	var won = make([]int, len(results))
+	for _, pot := range pots {
+		award(won, pot.Amount, potWinners(pot, results), results, oddChip)
+	}
+	return won
+}
+
+// DistributeHiLo is Distribute for split-pot games, using results from
+// Hand.EvaluateHiLo. Each pot is split between its best high hands and its
+// best qualifying lows; the odd chip from that split goes to the high half.
+// If nobody eligible for a pot has a low, the high hands scoop it.
+func DistributeHiLo(pots []Pot, results []*HandResult, oddChip OddChipRule) []int {
+	var won = make([]int, len(results))
+	var lows = make([]*HandResult, len(results))
+	for i, hr := range results {
+		lows[i] = lowOf(hr)
+	}
+
+	for _, pot := range pots {
+		var highWinners = potWinners(pot, results)
+		var lowWinners = Winners(eligibleResults(pot, lows)...)
+		if len(lowWinners) == 0 {
+			award(won, pot.Amount, highWinners, results, oddChip)
+			continue
+		}
+
+		var lowHalf = pot.Amount / 2
+		award(won, pot.Amount-lowHalf, highWinners, results, oddChip)
+		award(won, lowHalf, lowWinners, lows, oddChip)
+	}
+	return won
+}
+
+// eligibleResults returns a copy of results with everybody who isn't eligible
+// for the pot taken out
+func eligibleResults(pot Pot, results []*HandResult) []*HandResult {
+	var eligible = make([]*HandResult, len(results))
+	for _, i := range pot.Eligible {
+		eligible[i] = results[i]
+	}
+	return eligible
+}
+
+// potWinners returns the best eligible hands for the pot, or every eligible
+// player if none of them has a hand to show
+func potWinners(pot Pot, results []*HandResult) []int {
+	var winners = Winners(eligibleResults(pot, results)...)
+	if len(winners) == 0 {
+		return pot.Eligible
+	}
+	return winners
+}
+
+// award splits chips evenly among the winners, handing out any odd chips one
+// at a time in the order the rule chooses
+func award(won []int, chips int, winners []int, results []*HandResult, oddChip OddChipRule) {
+	if len(winners) == 0 {
+		return
+	}
+
+	var share, odd = chips / len(winners), chips % len(winners)
+	for _, i := range winners {
+		won[i] += share
+	}
+	var order = winners
+	if oddChip != nil && odd > 0 {
+		order = oddChip(winners, results)
+	}
+	for _, i := range order[:odd] {
+		won[i]++
+	}
+}
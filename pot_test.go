@@ -0,0 +1,228 @@
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildPots(t *testing.T) {
+	var tests = map[string]struct {
+		contribs []Contribution
+		expected []Pot
+	}{
+		"Single pot": {
+			[]Contribution{{100, false}, {100, false}, {100, false}},
+			[]Pot{{300, []int{0, 1, 2}}},
+		},
+		"Short all-in": {
+			[]Contribution{{50, false}, {100, false}, {100, false}},
+			[]Pot{{150, []int{0, 1, 2}}, {100, []int{1, 2}}},
+		},
+		"Two side pots": {
+			[]Contribution{{25, false}, {50, false}, {100, false}, {100, false}},
+			[]Pot{{100, []int{0, 1, 2, 3}}, {75, []int{1, 2, 3}}, {100, []int{2, 3}}},
+		},
+		"Folded chips": {
+			[]Contribution{{100, true}, {50, false}, {200, false}},
+			[]Pot{{150, []int{1, 2}}, {200, []int{2}}},
+		},
+		"Folded above everybody": {
+			[]Contribution{{300, true}, {100, false}, {100, false}},
+			[]Pot{{500, []int{1, 2}}},
+		},
+		"Uncalled": {
+			[]Contribution{{300, false}, {100, false}},
+			[]Pot{{200, []int{0, 1}}, {200, []int{0}}},
+		},
+		"Not dealt in": {
+			[]Contribution{{}, {10, false}, {10, false}},
+			[]Pot{{20, []int{1, 2}}},
+		},
+		"Live player put in nothing": {
+			[]Contribution{{10, true}, {0, false}, {5, true}},
+			[]Pot{{15, []int{1}}},
+		},
+		"Everybody folded": {
+			[]Contribution{{10, true}, {5, true}},
+			[]Pot{{15, nil}},
+		},
+		"No chips": {
+			[]Contribution{{}, {}},
+			nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var pots = BuildPots(tc.contribs)
+			if !reflect.DeepEqual(pots, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, pots)
+			}
+
+			var total int
+			for _, c := range tc.contribs {
+				total += c.Amount
+			}
+			for _, pot := range pots {
+				total -= pot.Amount
+			}
+			if total != 0 {
+				t.Errorf("Expected the pots to add up to the contributions, off by %d", total)
+			}
+		})
+	}
+}
+
+// scores returns fake results with the given scores, where zero means no hand
+func scores(s ...uint16) []*HandResult {
+	var results = make([]*HandResult, len(s))
+	for i, score := range s {
+		if score != 0 {
+			results[i] = &HandResult{Score: score}
+		}
+	}
+	return results
+}
+
+func TestDistribute(t *testing.T) {
+	var tests = map[string]struct {
+		contribs []Contribution
+		results  []*HandResult
+		button   int
+		expected []int
+	}{
+		"Short stack wins main": {
+			[]Contribution{{50, false}, {100, false}, {100, false}},
+			scores(1, 10, 20), 0,
+			[]int{150, 100, 0},
+		},
+		"Big stack wins everything": {
+			[]Contribution{{50, false}, {100, false}, {100, false}},
+			scores(20, 1, 10), 0,
+			[]int{0, 250, 0},
+		},
+		"Folded player can't win": {
+			[]Contribution{{100, true}, {100, false}, {100, false}},
+			scores(0, 10, 20), 0,
+			[]int{0, 300, 0},
+		},
+		"Uncontested": {
+			[]Contribution{{10, true}, {50, false}, {10, true}},
+			scores(0, 0, 0), 0,
+			[]int{0, 70, 0},
+		},
+		"Odd chip left of button": {
+			[]Contribution{{5, false}, {5, true}, {5, false}},
+			scores(10, 0, 10), 0,
+			[]int{7, 0, 8},
+		},
+		"Odd chip wraps around": {
+			[]Contribution{{5, false}, {5, true}, {5, false}},
+			scores(10, 0, 10), 2,
+			[]int{8, 0, 7},
+		},
+		"Tied side pot": {
+			[]Contribution{{31, false}, {100, false}, {100, false}, {100, false}},
+			scores(1, 10, 10, 20), 3,
+			[]int{124, 104, 103, 0},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var rule = OddChipLeftOfButton(tc.button, len(tc.contribs))
+			var won = Distribute(BuildPots(tc.contribs), tc.results, rule)
+			if !reflect.DeepEqual(won, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, won)
+			}
+		})
+	}
+}
+
+func TestOddChipBySuit(t *testing.T) {
+	// Both players make A-K-Q-J-7, but the jack of spades outranks the jack of
+	// hearts
+	var r = mustEvaluate(t, false, "Ah Kd Qc 7s 2d", "Jh 4c", "Js 3c", "9c 8c")
+	var won = Distribute(BuildPots([]Contribution{{5, false}, {5, false}, {5, false}}), r, OddChipBySuit)
+	if !reflect.DeepEqual(won, []int{7, 8, 0}) {
+		t.Errorf("Expected the odd chip to go to the Js, got %v", won)
+	}
+
+	// No rule just goes by index
+	won = Distribute(BuildPots([]Contribution{{5, false}, {5, false}, {5, false}}), r, nil)
+	if !reflect.DeepEqual(won, []int{8, 7, 0}) {
+		t.Errorf("Expected the odd chip to go to the first winner, got %v", won)
+	}
+}
+
+func TestDistributeHiLo(t *testing.T) {
+	var hiLo = func(high, low uint16) *HandResult {
+		var hr = &HandResult{Score: high}
+		if low != 0 {
+			hr.Low = &HandResult{Score: low}
+		}
+		return hr
+	}
+
+	var tests = map[string]struct {
+		contribs []Contribution
+		results  []*HandResult
+		expected []int
+	}{
+		"Split": {
+			[]Contribution{{50, false}, {51, false}},
+			[]*HandResult{hiLo(1, 0), hiLo(10, 5)},
+			[]int{50, 51},
+		},
+		"Scoop": {
+			[]Contribution{{50, false}, {50, false}},
+			[]*HandResult{hiLo(1, 3), hiLo(10, 5)},
+			[]int{100, 0},
+		},
+		"No low": {
+			[]Contribution{{50, false}, {50, false}},
+			[]*HandResult{hiLo(1, 0), hiLo(10, 0)},
+			[]int{100, 0},
+		},
+		"Quartered": {
+			[]Contribution{{40, false}, {40, false}, {40, false}},
+			[]*HandResult{hiLo(1, 0), hiLo(10, 5), hiLo(20, 5)},
+			[]int{60, 30, 30},
+		},
+		"Low only in main pot": {
+			[]Contribution{{20, false}, {100, false}, {100, false}},
+			[]*HandResult{hiLo(30, 5), hiLo(1, 0), hiLo(10, 0)},
+			[]int{30, 190, 0},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var won = DistributeHiLo(BuildPots(tc.contribs), tc.results, OddChipLeftOfButton(0, len(tc.contribs)))
+			if !reflect.DeepEqual(won, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, won)
+			}
+		})
+	}
+}
+
+func TestTableSidePots(t *testing.T) {
+	// Three all in with different stacks: whatever happens, nobody can win
+	// more from each opponent than they put in
+	for seed := int64(0); seed < 20; seed++ {
+		var table = newTestTable(t, seed, 100, 300, 600)
+		mustAct(t, table, raiseTo(100), raiseTo(300), call)
+
+		if table.InProgress() {
+			t.Fatalf("Expected the hand to be over")
+		}
+		var won = table.Won()
+		if won[0] > 300 || won[0]+won[1]+won[2] != 700 {
+			t.Fatalf("Seed %d: impossible winnings %v", seed, won)
+		}
+		if seat(t, table, 2).Stack < 300 || chipTotal(table) != 1000 {
+			t.Fatalf("Seed %d: the big stack should only risk 300, got stacks %d, %d, %d",
+				seed, seat(t, table, 0).Stack, seat(t, table, 1).Stack, seat(t, table, 2).Stack)
+		}
+	}
+}
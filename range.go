@@ -0,0 +1,729 @@
+package poker
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// comboKind tells us which combos of two ranks a piece of range notation
+// means: a pocket pair, suited, offsuit, or (when there's no s or o) both
+type comboKind int
+
+const (
+	kindPair comboKind = iota
+	kindSuited
+	kindOffsuit
+	kindAny
+)
+
+var kindSuffix = map[comboKind]string{
+	kindSuited:  "s",
+	kindOffsuit: "o",
+}
+
+// maxComboCards is the most cards a combo can have: six-card Omaha
+const maxComboCards = 6
+
+// comboKey is a combo's cards in the order we always store them (see
+// orderCards), padded with zero cards, so it can be used as a map key
+type comboKey [maxComboCards]Card
+
+// newComboKey returns the key for the given cards
+func newComboKey(cards ...Card) comboKey {
+	var k comboKey
+	copy(k[:], cards)
+	orderCards(k[:len(cards)])
+	return k
+}
+
+// cards returns the key's cards, without the padding
+func (k comboKey) cards() CardList {
+	var n = 0
+	for n < maxComboCards && k[n] != 0 {
+		n++
+	}
+	return append(CardList(nil), k[:n]...)
+}
+
+// Combo is a single starting hand within a Range, along with how much weight
+// the range gives it. A weight of 1 means the hand is always in the range,
+// 0.5 means it's in the range half the time, and so on.
+type Combo struct {
+	Cards  CardList
+	Weight float64
+}
+
+// Range is a weighted set of starting hands, such as "every pocket pair from
+// tens up, and ace-king suited half the time". Hold 'em ranges come from
+// ParseRange and Omaha ranges from ParseOmahaRange; every combo in a range
+// has the same number of cards.
+type Range struct {
+	size    int
+	weights map[comboKey]float64
+}
+
+// ParseRange converts standard range notation into a Range. Hands are
+// separated by commas and/or spaces, and any of them can be followed by a
+// colon and a weight between 0 and 1, e.g., "AKs:0.5". Supported notation:
+//
+//   - Pairs: "TT", "TT+" (tens or better), "TT-77"
+//   - Two ranks: "AKs" (suited), "AKo" (offsuit), "AK" (either)
+//   - Better kickers: "AQs+" means AQs and AKs
+//   - Kicker spans: "A2s-A5s"
+//   - Connector spans: "76s-54s" means 76s, 65s, and 54s
+//   - Exact cards: "AhKh"
+//
+// If a hand is listed more than once, the last weight given wins.
+func ParseRange(s string) (*Range, error) {
+	var r = &Range{size: 2, weights: make(map[comboKey]float64)}
+	var tokens = strings.FieldsFunc(s, func(c rune) bool {
+		return c == ',' || unicode.IsSpace(c)
+	})
+
+	for _, token := range tokens {
+		var body, weight, err = splitWeight(token)
+		if err != nil {
+			return nil, err
+		}
+
+		var combos []comboKey
+		combos, err = parseRangeBody(body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", err, token)
+		}
+		for _, c := range combos {
+			r.weights[c] = weight
+		}
+	}
+
+	return r, nil
+}
+
+// splitWeight pulls the optional weight off the end of a range token
+func splitWeight(token string) (string, float64, error) {
+	var idx = strings.IndexByte(token, ':')
+	if idx < 0 {
+		return token, 1, nil
+	}
+
+	var w, err = strconv.ParseFloat(token[idx+1:], 64)
+	if err != nil || math.IsNaN(w) || w <= 0 || w > 1 {
+		return "", 0, fmt.Errorf("%w: %q has a weight which isn't above 0 and at most 1", ErrInvalidRange, token)
+	}
+	return token[:idx], w, nil
+}
+
+// parseRangeBody expands a single piece of range notation, minus its weight,
+// into the combos it represents
+func parseRangeBody(body string) ([]comboKey, error) {
+	// Exact cards, e.g., "AhKh"
+	if len(body) == 4 && charToCardSuit[body[1]] != 0 {
+		var c1, err1 = NewCardString(body[:2])
+		var c2, err2 = NewCardString(body[2:])
+		if err1 != nil || err2 != nil || c1 == c2 {
+			return nil, ErrInvalidRange
+		}
+		return []comboKey{orderCombo(c1, c2)}, nil
+	}
+
+	if idx := strings.IndexByte(body, '-'); idx >= 0 {
+		return parseSpan(body[:idx], body[idx+1:])
+	}
+
+	var plus = strings.HasSuffix(body, "+")
+	var hi, lo, kind, err = parseHandClass(strings.TrimSuffix(body, "+"))
+	if err != nil {
+		return nil, err
+	}
+	if !plus {
+		return classCombos(hi, lo, kind), nil
+	}
+
+	var combos []comboKey
+	if kind == kindPair {
+		for r := hi; r <= Ace; r++ {
+			combos = append(combos, classCombos(r, r, kind)...)
+		}
+		return combos, nil
+	}
+	for k := lo; k < hi; k++ {
+		combos = append(combos, classCombos(hi, k, kind)...)
+	}
+	return combos, nil
+}
+
+// parseSpan expands notation like "TT-77", "A2s-A5s", or "76s-54s"
+func parseSpan(from, to string) ([]comboKey, error) {
+	var hi1, lo1, kind1, err1 = parseHandClass(from)
+	var hi2, lo2, kind2, err2 = parseHandClass(to)
+	if err1 != nil || err2 != nil || kind1 != kind2 {
+		return nil, ErrInvalidRange
+	}
+
+	// Make sure the first hand is the lower one
+	if hi1 > hi2 || (hi1 == hi2 && lo1 > lo2) {
+		hi1, lo1, hi2, lo2 = hi2, lo2, hi1, lo1
+	}
+
+	var combos []comboKey
+	switch {
+	case kind1 == kindPair:
+		for r := hi1; r <= hi2; r++ {
+			combos = append(combos, classCombos(r, r, kind1)...)
+		}
+	case hi1 == hi2:
+		for k := lo1; k <= lo2; k++ {
+			combos = append(combos, classCombos(hi1, k, kind1)...)
+		}
+	case hi2-hi1 == lo2-lo1:
+		for d := CardRank(0); d <= hi2-hi1; d++ {
+			combos = append(combos, classCombos(hi1+d, lo1+d, kind1)...)
+		}
+	default:
+		return nil, ErrInvalidRange
+	}
+	return combos, nil
+}
+
+// parseHandClass reads two ranks and an optional "s" or "o", e.g., "AKs" or
+// "77", returning the higher rank first
+func parseHandClass(s string) (hi, lo CardRank, kind comboKind, err error) {
+	if len(s) < 2 || len(s) > 3 {
+		return 0, 0, 0, ErrInvalidRange
+	}
+
+	var ok1, ok2 bool
+	hi, ok1 = charToCardRank[s[0]]
+	lo, ok2 = charToCardRank[s[1]]
+	if !ok1 || !ok2 {
+		return 0, 0, 0, ErrInvalidRange
+	}
+	if lo > hi {
+		hi, lo = lo, hi
+	}
+
+	kind = kindAny
+	if len(s) == 3 {
+		switch s[2] {
+		case 's':
+			kind = kindSuited
+		case 'o':
+			kind = kindOffsuit
+		default:
+			return 0, 0, 0, ErrInvalidRange
+		}
+	}
+
+	if hi == lo {
+		if kind != kindAny {
+			return 0, 0, 0, ErrInvalidRange
+		}
+		kind = kindPair
+	}
+	return hi, lo, kind, nil
+}
+
+// orderCombo returns the key for two cards
+func orderCombo(c1, c2 Card) comboKey {
+	return newComboKey(c1, c2)
+}
+
+// orderCards puts cards in the order we always store them: highest rank
+// first, with cards of the same rank in deck order by suit
+func orderCards(cards CardList) {
+	sort.Slice(cards, func(i, j int) bool {
+		if cards[i].Rank() != cards[j].Rank() {
+			return cards[i].Rank() > cards[j].Rank()
+		}
+		return cards[i].Suit() < cards[j].Suit()
+	})
+}
+
+// classCombos returns every combo of the two ranks matching the given kind
+func classCombos(hi, lo CardRank, kind comboKind) []comboKey {
+	var combos []comboKey
+	for i, s1 := range allSuits {
+		for j, s2 := range allSuits {
+			var keep bool
+			switch kind {
+			case kindPair:
+				keep = j > i
+			case kindSuited:
+				keep = i == j
+			case kindOffsuit:
+				keep = i != j
+			case kindAny:
+				keep = true
+			}
+			if keep {
+				combos = append(combos, comboKey{NewCard(hi, s1), NewCard(lo, s2)})
+			}
+		}
+	}
+	return combos
+}
+
+// Len returns the number of combos in the range, regardless of weight
+func (r *Range) Len() int {
+	return len(r.weights)
+}
+
+// Size returns how many cards each combo in the range has: two for Hold 'em,
+// or four to six for Omaha. An empty Omaha range has a size of zero.
+func (r *Range) Size() int {
+	return r.size
+}
+
+// Weight returns how much weight the range gives the cards, in any order, or
+// zero if they aren't in the range
+func (r *Range) Weight(cards ...Card) float64 {
+	if len(cards) > maxComboCards {
+		return 0
+	}
+	return r.weights[newComboKey(cards...)]
+}
+
+// Combos returns every combo in the range, best ranks first, with the higher
+// cards first in each combo
+func (r *Range) Combos() []Combo {
+	var keys = make([]comboKey, 0, len(r.weights))
+	for k := range r.weights {
+		keys = append(keys, k)
+	}
+	sortCombos(keys)
+
+	var combos = make([]Combo, len(keys))
+	for i, k := range keys {
+		combos[i] = Combo{Cards: k.cards(), Weight: r.weights[k]}
+	}
+	return combos
+}
+
+// sortCombos orders combos by their first card's rank, then the second
+// card's rank, and so on, then by the suits in deck order
+func sortCombos(keys []comboKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		var a, b = keys[i], keys[j]
+		for n := 0; n < maxComboCards; n++ {
+			if a[n].Rank() != b[n].Rank() {
+				return a[n].Rank() > b[n].Rank()
+			}
+		}
+		for n := 0; n < maxComboCards; n++ {
+			if a[n].Suit() != b[n].Suit() {
+				return a[n].Suit() < b[n].Suit()
+			}
+		}
+		return false
+	})
+}
+
+// Without returns a copy of the range with every combo that uses any of the
+// dead cards taken out
+func (r *Range) Without(dead CardList) *Range {
+	var blocked = dead.Set()
+	var out = &Range{size: r.size, weights: make(map[comboKey]float64, len(r.weights))}
+	for k, w := range r.weights {
+		if k.cards().Set().Intersect(blocked) == 0 {
+			out.weights[k] = w
+		}
+	}
+	return out
+}
+
+// classWeight returns the weight most of a class's combos have, e.g., every
+// combo of AKs, if the range has all of them. Ties go to the higher weight.
+// Combos with a different weight have to be written separately.
+func (r *Range) classWeight(hi, lo CardRank, kind comboKind) (float64, bool) {
+	var counts = make(map[float64]int)
+	for _, c := range classCombos(hi, lo, kind) {
+		var w, ok = r.weights[c]
+		if !ok {
+			return 0, false
+		}
+		counts[w]++
+	}
+
+	var weight float64
+	for w, n := range counts {
+		if n > counts[weight] || n == counts[weight] && w > weight {
+			weight = w
+		}
+	}
+	return weight, true
+}
+
+// classRun is a stretch of hand classes next to one another which share a
+// weight, e.g., ATs, AJs, and AQs, stored by the lower rank of each
+type classRun struct {
+	top    CardRank
+	bottom CardRank
+	weight float64
+}
+
+// classKey identifies one class of two-rank hands, e.g., AKs or T9
+type classKey struct {
+	hi   CardRank
+	lo   CardRank
+	kind comboKind
+}
+
+// String returns the range in compact, canonical notation: pairs first, then
+// each high card's hands from aces down, then any leftover exact combos. A
+// class with a few combos at another weight is written at its most common
+// weight, with the others after it, e.g., "AKs, AhKh:0.5". Classes that
+// don't share a high card with their neighbors, like 76s, 65s,
+// and 54s, are written as connector spans, e.g., "76s-54s". Parsing the
+// result gives back the same range.
+func (r *Range) String() string {
+	if r.size != 2 {
+		return r.omahaString()
+	}
+
+	var tokens []string
+	var covered = make(map[comboKey]float64)
+	var cover = func(hi, lo CardRank, kind comboKind, weight float64) {
+		for _, c := range classCombos(hi, lo, kind) {
+			covered[c] = weight
+		}
+	}
+
+	// Pairs, from aces down
+	var pairs []classRun
+	for i := int(Ace); i >= int(Deuce); i-- {
+		var rank = CardRank(i)
+		if w, ok := r.classWeight(rank, rank, kindPair); ok {
+			pairs = appendRun(pairs, rank, w)
+			cover(rank, rank, kindPair, w)
+		}
+	}
+	for _, run := range pairs {
+		tokens = append(tokens, pairToken(run))
+	}
+
+	// Two ranks: suited and offsuit classes with the same weight are written
+	// once, without the suffix
+	var runs = make(map[CardRank]map[comboKind][]classRun)
+	var singles = make(map[classKey]float64)
+	for hi := Ace; hi > Deuce; hi-- {
+		runs[hi] = make(map[comboKind][]classRun)
+		for i := int(hi) - 1; i >= int(Deuce); i-- {
+			var lo = CardRank(i)
+			var sw, sok = r.classWeight(hi, lo, kindSuited)
+			var ow, ook = r.classWeight(hi, lo, kindOffsuit)
+			switch {
+			case sok && ook && sw == ow:
+				runs[hi][kindAny] = appendRun(runs[hi][kindAny], lo, sw)
+				cover(hi, lo, kindAny, sw)
+			default:
+				if sok {
+					runs[hi][kindSuited] = appendRun(runs[hi][kindSuited], lo, sw)
+					cover(hi, lo, kindSuited, sw)
+				}
+				if ook {
+					runs[hi][kindOffsuit] = appendRun(runs[hi][kindOffsuit], lo, ow)
+					cover(hi, lo, kindOffsuit, ow)
+				}
+			}
+		}
+		for kind, kindRuns := range runs[hi] {
+			for _, run := range kindRuns {
+				if run.top == run.bottom {
+					singles[classKey{hi, run.top, kind}] = run.weight
+				}
+			}
+		}
+	}
+
+	// Classes left on their own get chained into connector spans with the
+	// same gap, kind, and weight, starting from the highest
+	var spans = make(map[classKey]CardRank)
+	var chained = make(map[classKey]bool)
+	for hi := Ace; hi > Deuce; hi-- {
+		for lo := hi - 1; ; lo-- {
+			for _, kind := range []comboKind{kindAny, kindSuited, kindOffsuit} {
+				var top = classKey{hi, lo, kind}
+				var w, ok = singles[top]
+				if !ok || chained[top] {
+					continue
+				}
+				var n CardRank
+				for n = 1; n <= lo; n++ {
+					var next = classKey{hi - n, lo - n, kind}
+					if nw, ok := singles[next]; !ok || nw != w {
+						break
+					}
+					chained[next] = true
+				}
+				if n > 1 {
+					spans[top] = hi - n + 1
+				}
+			}
+			if lo == Deuce {
+				break
+			}
+		}
+	}
+
+	for hi := Ace; hi > Deuce; hi-- {
+		for _, kind := range []comboKind{kindAny, kindSuited, kindOffsuit} {
+			for _, run := range runs[hi][kind] {
+				var key = classKey{hi, run.top, kind}
+				switch {
+				case run.top == run.bottom && chained[key]:
+				case run.top == run.bottom && spans[key] != 0:
+					tokens = append(tokens, connectorToken(key, spans[key], run.weight))
+				default:
+					tokens = append(tokens, kickerToken(hi, kind, run))
+				}
+			}
+		}
+	}
+
+	// Anything left over, or at a different weight than its class, has to be
+	// spelled out card by card
+	var leftover []comboKey
+	for k, w := range r.weights {
+		if cw, ok := covered[k]; !ok || cw != w {
+			leftover = append(leftover, k)
+		}
+	}
+	sortCombos(leftover)
+	for _, k := range leftover {
+		tokens = append(tokens, exactToken(k)+weightSuffix(r.weights[k]))
+	}
+
+	return strings.Join(tokens, ", ")
+}
+
+// appendRun adds a rank to the list of runs, extending the last run if the
+// rank is right below it and has the same weight. Ranks must be added from
+// highest to lowest.
+func appendRun(runs []classRun, rank CardRank, weight float64) []classRun {
+	var n = len(runs)
+	if n > 0 && runs[n-1].bottom == rank+1 && runs[n-1].weight == weight {
+		runs[n-1].bottom = rank
+		return runs
+	}
+	return append(runs, classRun{top: rank, bottom: rank, weight: weight})
+}
+
+func pairToken(run classRun) string {
+	var bottom = run.bottom.String() + run.bottom.String()
+	var token = bottom
+	switch {
+	case run.top == run.bottom:
+	case run.top == Ace:
+		token += "+"
+	default:
+		token = run.top.String() + run.top.String() + "-" + bottom
+	}
+	return token + weightSuffix(run.weight)
+}
+
+func kickerToken(hi CardRank, kind comboKind, run classRun) string {
+	var suffix = kindSuffix[kind]
+	var bottom = hi.String() + run.bottom.String() + suffix
+	var token = bottom
+	switch {
+	case run.top == run.bottom:
+	case run.top == hi-1:
+		token += "+"
+	default:
+		token = hi.String() + run.top.String() + suffix + "-" + bottom
+	}
+	return token + weightSuffix(run.weight)
+}
+
+// connectorToken writes a span of classes with the same gap, from top down to
+// the class whose high card is bottom, e.g., "76s-54s"
+func connectorToken(top classKey, bottom CardRank, weight float64) string {
+	var suffix = kindSuffix[top.kind]
+	var gap = top.hi - top.lo
+	return top.hi.String() + top.lo.String() + suffix + "-" +
+		bottom.String() + (bottom - gap).String() + suffix + weightSuffix(weight)
+}
+
+// exactToken writes a combo card by card, e.g., "AhKh"
+func exactToken(k comboKey) string {
+	var b strings.Builder
+	for _, c := range k.cards() {
+		b.WriteString(c.String())
+	}
+	return b.String()
+}
+
+func weightSuffix(w float64) string {
+	if w == 1 {
+		return ""
+	}
+	return ":" + strconv.FormatFloat(w, 'g', -1, 64)
+}
+
+// ParseOmahaRange converts Omaha range notation into a Range. Hands are
+// separated by commas and/or spaces and can be weighted just like in
+// ParseRange, but every hand is four to six cards, and all of them must be
+// the same size. Supported notation:
+//
+//   - Ranks: "AAKK" means every combo of two aces and two kings, in any suits
+//   - Exact cards: "AsAhKsKh"
+func ParseOmahaRange(s string) (*Range, error) {
+	var r = &Range{weights: make(map[comboKey]float64)}
+	var tokens = strings.FieldsFunc(s, func(c rune) bool {
+		return c == ',' || unicode.IsSpace(c)
+	})
+
+	for _, token := range tokens {
+		var body, weight, err = splitWeight(token)
+		if err != nil {
+			return nil, err
+		}
+
+		var combos []comboKey
+		var size int
+		combos, size, err = parseOmahaBody(body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", err, token)
+		}
+		if r.size != 0 && size != r.size {
+			return nil, fmt.Errorf("%w: %q has %d cards, but other hands have %d", ErrInvalidRange, token, size, r.size)
+		}
+		r.size = size
+		for _, c := range combos {
+			r.weights[c] = weight
+		}
+	}
+
+	return r, nil
+}
+
+// parseOmahaBody expands a single Omaha hand, minus its weight, into the
+// combos it represents, and returns how many cards each one has
+func parseOmahaBody(body string) ([]comboKey, int, error) {
+	// Exact cards, e.g., "AsAhKsKh"
+	if len(body) > 1 && charToCardSuit[body[1]] != 0 {
+		var n = len(body) / 2
+		if len(body)%2 != 0 || n < 4 || n > maxComboCards {
+			return nil, 0, ErrInvalidRange
+		}
+		var cards = make(CardList, n)
+		var seen CardSet
+		for i := range cards {
+			var c, err = NewCardString(body[i*2 : i*2+2])
+			if err != nil || seen.Contains(c) {
+				return nil, 0, ErrInvalidRange
+			}
+			seen.Add(c)
+			cards[i] = c
+		}
+		return []comboKey{newComboKey(cards...)}, n, nil
+	}
+
+	if len(body) < 4 || len(body) > maxComboCards {
+		return nil, 0, ErrInvalidRange
+	}
+	var ranks = make([]CardRank, len(body))
+	for i := range ranks {
+		var ok bool
+		ranks[i], ok = charToCardRank[body[i]]
+		if !ok {
+			return nil, 0, ErrInvalidRange
+		}
+	}
+
+	var combos = rankCombos(ranks)
+	if len(combos) == 0 {
+		return nil, 0, ErrInvalidRange
+	}
+	return combos, len(ranks), nil
+}
+
+// rankCombos returns every combo of cards with the given ranks, in any suits
+func rankCombos(ranks []CardRank) []comboKey {
+	var found = make(map[comboKey]bool)
+	var combos []comboKey
+	var cards = make(CardList, len(ranks))
+	var used CardSet
+
+	var fill func(pos int)
+	fill = func(pos int) {
+		if pos == len(ranks) {
+			var k = newComboKey(cards...)
+			if !found[k] {
+				found[k] = true
+				combos = append(combos, k)
+			}
+			return
+		}
+		for _, s := range allSuits {
+			var c = NewCard(ranks[pos], s)
+			if used.Contains(c) {
+				continue
+			}
+			used.Add(c)
+			cards[pos] = c
+			fill(pos + 1)
+			used.Remove(c)
+		}
+	}
+	fill(0)
+
+	return combos
+}
+
+// omahaString is String for Omaha ranges: every combo of a set of ranks with
+// the same weight is written as just the ranks, e.g., "AAKK", and anything
+// else is spelled out card by card after those
+func (r *Range) omahaString() string {
+	var keys = make([]comboKey, 0, len(r.weights))
+	for k := range r.weights {
+		keys = append(keys, k)
+	}
+	sortCombos(keys)
+
+	var tokens []string
+	var checked = make(map[string]bool)
+	var covered = make(map[comboKey]bool)
+	for _, k := range keys {
+		var cards = k.cards()
+		var ranks = make([]CardRank, len(cards))
+		var pattern string
+		for i, c := range cards {
+			ranks[i] = c.Rank()
+			pattern += c.Rank().String()
+		}
+		if checked[pattern] {
+			continue
+		}
+		checked[pattern] = true
+
+		var combos = rankCombos(ranks)
+		var weight = r.weights[k]
+		var full = true
+		for _, c := range combos {
+			if w, ok := r.weights[c]; !ok || w != weight {
+				full = false
+				break
+			}
+		}
+		if !full {
+			continue
+		}
+		for _, c := range combos {
+			covered[c] = true
+		}
+		tokens = append(tokens, pattern+weightSuffix(weight))
+	}
+
+	for _, k := range keys {
+		if !covered[k] {
+			tokens = append(tokens, exactToken(k)+weightSuffix(r.weights[k]))
+		}
+	}
+
+	return strings.Join(tokens, ", ")
+}
@@ -0,0 +1,188 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// ComboEquity is how a single combo within a range fared. Its Trials are the
+// number of times the combo was dealt, so combos with lower weights will have
+// fewer trials.
+type ComboEquity struct {
+	Cards  CardList
+	Weight float64
+	EquityResult
+}
+
+// RangeEquityResult holds a range's overall outcomes, as well as the outcomes
+// of every combo in the range
+type RangeEquityResult struct {
+	EquityResult
+	Combos []ComboEquity
+}
+
+// rangeSeat holds one range's live combos, set up for weighted sampling
+type rangeSeat struct {
+	combos     []ComboEquity
+	cumulative []float64
+	total      float64
+	picked     int
+}
+
+// pick chooses one of the seat's combos at random, weighted by the range
+func (s *rangeSeat) pick(rnd *rand.Rand) int {
+	var i = sort.SearchFloat64s(s.cumulative, rnd.Float64()*s.total)
+	if i >= len(s.combos) {
+		i = len(s.combos) - 1
+	}
+	return i
+}
+
+// RangeEquity estimates each range's equity against the others, the same way
+// Equity does for fixed hands. On every trial, each range is dealt one of its
+// combos at random (respecting the range's weights, and never giving two
+// ranges the same card), then the board is completed and each hand is scored
+// just as Equity scores it. Ranges must all be Hold 'em ranges, or all Omaha ranges
+// with the same number of cards, where each hand has to use exactly two of
+// its cards.
+//
+// Combos which conflict with the board or dead cards are removed up front. If
+// a range has nothing left, or the ranges overlap so much that they can't be
+// dealt without sharing cards, an ErrInvalidRange error is returned.
+//
+// Each result has the range's overall outcomes, plus a breakdown by combo, in
+// the same order as Range.Combos.
+func RangeEquity(ranges []*Range, board, dead CardList, trials int, rndSource rand.Source) ([]RangeEquityResult, error) {
+	if trials < 1 {
+		return nil, fmt.Errorf("equity needs at least one trial, got %d", trials)
+	}
+	if len(ranges) < 2 {
+		return nil, fmt.Errorf("%w: equity needs at least two ranges", ErrInvalidPlayerCount)
+	}
+	if len(board) > 5 {
+		return nil, fmt.Errorf("%w: board can't have more than five cards", ErrInvalidCardCount)
+	}
+	for _, r := range ranges {
+		if r.Size() != ranges[0].Size() {
+			return nil, fmt.Errorf("%w: every range must have the same number of cards per hand", ErrInvalidCardCount)
+		}
+	}
+
+	var known = append(append(CardList(nil), board...), dead...)
+	var stub, err = remainingCards(known)
+	if err != nil {
+		return nil, err
+	}
+
+	var seats = make([]*rangeSeat, len(ranges))
+	for i, r := range ranges {
+		seats[i], err = newRangeSeat(r.Without(known))
+		if err != nil {
+			return nil, fmt.Errorf("range %d: %w", i, err)
+		}
+	}
+	if !canDeal(seats, 0, 0) {
+		return nil, fmt.Errorf("%w: ranges overlap too much to deal without sharing cards", ErrInvalidRange)
+	}
+
+	var hands = make([]CardList, len(ranges))
+	var fullBoard = make(CardList, 5)
+	copy(fullBoard, board)
+	var scratch = make(CardList, 7)
+	var scores = make([]uint16, len(ranges))
+	var results = make([]RangeEquityResult, len(ranges))
+
+	var rnd = rand.New(rndSource)
+	var live = make(CardList, len(stub))
+	var need = 5 - len(board)
+	for i := 0; i < trials; i++ {
+		var taken = dealRanges(seats, hands, rnd)
+
+		// Finish the board from whatever the ranges didn't take
+		live = live[:0]
+		for _, c := range stub {
+			if !taken.Contains(c) {
+				live = append(live, c)
+			}
+		}
+		if len(live) < need {
+			return nil, fmt.Errorf("%w: not enough cards left to complete the board", ErrInvalidCardCount)
+		}
+		for j := 0; j < need; j++ {
+			var k = j + rnd.Intn(len(live)-j)
+			live[j], live[k] = live[k], live[j]
+		}
+		copy(fullBoard[len(board):], live[:need])
+
+		var best, winners = scoreHands(hands, fullBoard, ranges[0].Size() != 2, scratch, scores)
+		for j, seat := range seats {
+			results[j].record(scores[j] == best, winners)
+			seat.combos[seat.picked].record(scores[j] == best, winners)
+		}
+	}
+
+	for i, seat := range seats {
+		results[i].Combos = seat.combos
+	}
+	return results, nil
+}
+
+// newRangeSeat prepares a range's combos for sampling
+func newRangeSeat(r *Range) (*rangeSeat, error) {
+	var combos = r.Combos()
+	if len(combos) == 0 {
+		return nil, fmt.Errorf("%w: no combos left after removing known cards", ErrInvalidRange)
+	}
+
+	var seat = &rangeSeat{
+		combos:     make([]ComboEquity, len(combos)),
+		cumulative: make([]float64, len(combos)),
+	}
+	for i, c := range combos {
+		seat.combos[i] = ComboEquity{Cards: c.Cards, Weight: c.Weight}
+		seat.total += c.Weight
+		seat.cumulative[i] = seat.total
+	}
+	return seat, nil
+}
+
+// canDeal returns true if every seat from the given one on can be dealt a
+// combo without any two sharing a card
+func canDeal(seats []*rangeSeat, from int, used CardSet) bool {
+	if from == len(seats) {
+		return true
+	}
+	for _, c := range seats[from].combos {
+		var cards = c.Cards.Set()
+		if used.Intersect(cards) == 0 && canDeal(seats, from+1, used.Union(cards)) {
+			return true
+		}
+	}
+	return false
+}
+
+// dealRanges picks a combo for every seat, starting over whenever two seats
+// would share a card so that every valid deal is as likely as its weights say
+// it should be. canDeal has to be checked first, or this never returns. The
+// picked combos are put into hands, and the set of cards they use is
+// returned.
+func dealRanges(seats []*rangeSeat, hands []CardList, rnd *rand.Rand) CardSet {
+	for {
+		var used CardSet
+		var ok = true
+		for i, seat := range seats {
+			seat.picked = seat.pick(rnd)
+			var cards = seat.combos[seat.picked].Cards.Set()
+			if used.Intersect(cards) != 0 {
+				ok = false
+				break
+			}
+			used = used.Union(cards)
+			hands[i] = seat.combos[seat.picked].Cards
+		}
+		if ok {
+			return used
+		}
+	}
+}
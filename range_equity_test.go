@@ -0,0 +1,231 @@
+package poker
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func mustParseRanges(t testing.TB, ranges ...string) []*Range {
+	var list = make([]*Range, len(ranges))
+	for i, s := range ranges {
+		var r, err = ParseRange(s)
+		if err != nil {
+			t.Fatalf("Unable to parse %q: %s", s, err)
+		}
+		list[i] = r
+	}
+	return list
+}
+
+func TestRangeEquity(t *testing.T) {
+	// Expected equities are exact, from ExactEquity (averaged over every
+	// matchup of combos, for the ranges)
+	var tests = map[string]struct {
+		ranges []string
+		board  string
+		equity []float64
+	}{
+		"Single combos match Equity": {[]string{"AsAh", "KsKh"}, "", []float64{0.8264, 0.1736}},
+		"Aces vs. kings, any suits":  {[]string{"AA", "KK"}, "", []float64{0.8195, 0.1805}},
+		"Flop":                       {[]string{"AhKh", "QsQc"}, "2h 7h 9c", []float64{0.5414, 0.4586}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var ranges = mustParseRanges(t, tc.ranges...)
+			var board, _ = ParseCards(tc.board)
+			var results, err = RangeEquity(ranges, board, nil, 20000, rand.NewSource(1))
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			for i, r := range results {
+				if r.Trials != 20000 {
+					t.Errorf("Range %d: expected 20000 trials, got %d", i, r.Trials)
+				}
+				if math.Abs(r.Equity()-tc.equity[i]) > 3*r.StdErr() {
+					t.Errorf("Range %d: equity %0.4f (±%0.4f) is too far from the expected %0.4f",
+						i, r.Equity(), r.StdErr(), tc.equity[i])
+				}
+
+				var comboTrials int
+				for _, c := range r.Combos {
+					comboTrials += c.Trials
+				}
+				if comboTrials != r.Trials {
+					t.Errorf("Range %d: combos add up to %d trials, but the range had %d", i, comboTrials, r.Trials)
+				}
+			}
+		})
+	}
+}
+
+func TestRangeEquityCombos(t *testing.T) {
+	// Against kings, the aces in the range are big favorites while the AK
+	// combos are big underdogs
+	var ranges = mustParseRanges(t, "AA, AKs", "KsKh")
+	var results, err = RangeEquity(ranges, nil, nil, 40000, rand.NewSource(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// AsKs and AhKh can never be dealt against the kings, leaving 6 combos of
+	// aces and two of AKs to share the trials
+	var combos = results[0].Combos
+	if len(combos) != 10 {
+		t.Fatalf("Expected 10 combos, got %d", len(combos))
+	}
+	var aces, blocked, ak = combos[0], combos[6], combos[9]
+	if aces.Cards.String() != "As Ah" || blocked.Cards.String() != "As Ks" || ak.Cards.String() != "Ac Kc" {
+		t.Fatalf("Got unexpected combos %s, %s, and %s", aces.Cards, blocked.Cards, ak.Cards)
+	}
+	if blocked.Trials != 0 {
+		t.Errorf("Expected %s to never be dealt, but it was dealt %d times", blocked.Cards, blocked.Trials)
+	}
+	if aces.Equity() < 0.75 || ak.Equity() > 0.4 {
+		t.Errorf("Expected aces to be about 80%% and AKs about 33%%; got %0.3f and %0.3f", aces.Equity(), ak.Equity())
+	}
+
+	// Every combo has the same weight here, so each live combo should be
+	// dealt about an eighth of the time
+	for _, c := range combos {
+		if c.Trials == 0 {
+			continue
+		}
+		var frac = float64(c.Trials) / float64(results[0].Trials)
+		if math.Abs(frac-0.125) > 0.01 {
+			t.Errorf("%s was dealt %0.3f of the time; expected about 0.125", c.Cards, frac)
+		}
+	}
+}
+
+func TestRangeEquityOmaha(t *testing.T) {
+	var parse = func(s string) *Range {
+		var r, err = ParseOmahaRange(s)
+		if err != nil {
+			t.Fatalf("Unable to parse %q: %s", s, err)
+		}
+		return r
+	}
+
+	// A single combo each has to agree with ExactEquity
+	var results, err = RangeEquity([]*Range{parse("AsAhKsKh"), parse("QdJdTd9c")}, nil, nil, 20000, rand.NewSource(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if math.Abs(results[0].Equity()-0.6690) > 3*results[0].StdErr() {
+		t.Errorf("Expected about 0.6690, got %0.4f (±%0.4f)", results[0].Equity(), results[0].StdErr())
+	}
+
+	results, err = RangeEquity([]*Range{parse("AAKK"), parse("QdJdTd9c")}, nil, nil, 2000, rand.NewSource(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	var comboTrials int
+	for _, c := range results[0].Combos {
+		comboTrials += c.Trials
+	}
+	if len(results[0].Combos) != 36 || comboTrials != results[0].Trials {
+		t.Errorf("Expected 36 combos sharing %d trials, got %d combos with %d", results[0].Trials, len(results[0].Combos), comboTrials)
+	}
+
+	// Omaha hands have to use exactly two hole cards: four hearts in the hand
+	// and one on the board is no flush
+	var board, _ = ParseCards("Ah 2c 7d 8s 3d")
+	results, err = RangeEquity([]*Range{parse("KhQhJh9h"), parse("2d2s5cKc")}, board, nil, 10, rand.NewSource(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if results[1].Wins != 10 {
+		t.Errorf("Expected trip twos to beat ace-high every time, got %#v", results[1].EquityResult)
+	}
+
+	var holdem, _ = ParseRange("AA")
+	_, err = RangeEquity([]*Range{holdem, parse("KKQQ")}, nil, nil, 10, rand.NewSource(1))
+	if !errors.Is(err, ErrInvalidCardCount) {
+		t.Errorf("Expected mixing Hold 'em and Omaha to be %q, got %v", ErrInvalidCardCount, err)
+	}
+}
+
+func TestRangeEquityWeights(t *testing.T) {
+	var ranges = mustParseRanges(t, "AsAh, KsKh:0.25", "QQ")
+	var results, err = RangeEquity(ranges, nil, nil, 20000, rand.NewSource(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var aces, kings = results[0].Combos[0], results[0].Combos[1]
+	var frac = float64(kings.Trials) / float64(aces.Trials+kings.Trials)
+	if math.Abs(frac-0.2) > 0.02 {
+		t.Errorf("Kings were dealt %0.3f of the time; expected about 0.2", frac)
+	}
+}
+
+func TestRangeEquityOverlap(t *testing.T) {
+	// Both ranges almost always pick AsAh, so only about one deal in 5,000
+	// works, but there's always a way to deal them
+	var ranges = mustParseRanges(t, "AsAh, KsKh:0.0001", "AsAh, KsKh:0.0001")
+	var results, err = RangeEquity(ranges, nil, nil, 100, rand.NewSource(1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for i, r := range results {
+		if r.Trials != 100 || r.Combos[0].Trials+r.Combos[1].Trials != 100 {
+			t.Errorf("Range %d: expected 100 trials, got %d", i, r.Trials)
+		}
+	}
+}
+
+func TestRangeEquityTrialsDontAllocate(t *testing.T) {
+	var ranges = mustParseRanges(t, "AK", "QQ, JJ")
+	var omaha, _ = ParseOmahaRange("AAKK")
+	var omaha2, _ = ParseOmahaRange("QQJJ")
+	var src = rand.NewSource(1)
+
+	var few = testing.AllocsPerRun(10, func() { RangeEquity(ranges, nil, nil, 10, src) })
+	var many = testing.AllocsPerRun(10, func() { RangeEquity(ranges, nil, nil, 1000, src) })
+	if few != many {
+		t.Errorf("Hold'em: 10 trials made %0.f allocations, but 1000 trials made %0.f", few, many)
+	}
+
+	var both = []*Range{omaha, omaha2}
+	few = testing.AllocsPerRun(10, func() { RangeEquity(both, nil, nil, 10, src) })
+	many = testing.AllocsPerRun(10, func() { RangeEquity(both, nil, nil, 1000, src) })
+	if few != many {
+		t.Errorf("Omaha: 10 trials made %0.f allocations, but 1000 trials made %0.f", few, many)
+	}
+}
+
+func TestRangeEquityErrors(t *testing.T) {
+	var tests = map[string]struct {
+		ranges []string
+		board  string
+		dead   string
+		err    error
+	}{
+		"One range":        {[]string{"AA"}, "", "", ErrInvalidPlayerCount},
+		"All dead":         {[]string{"AsAh", "KK"}, "", "As", ErrInvalidRange},
+		"Blocked by board": {[]string{"AsAh", "KK"}, "As 2c 3d", "", ErrInvalidRange},
+		"Same combo":       {[]string{"AsAh", "AsAh"}, "", "", ErrInvalidRange},
+		"Duplicate dead":   {[]string{"AA", "KK"}, "2c 3d 4h", "2c", ErrDuplicateCard},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var ranges = mustParseRanges(t, tc.ranges...)
+			var board, _ = ParseCards(tc.board)
+			var dead, _ = ParseCards(tc.dead)
+			var _, err = RangeEquity(ranges, board, dead, 10, rand.NewSource(1))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("Expected error to be %q, got %v", tc.err, err)
+			}
+		})
+	}
+
+	var _, err = RangeEquity(mustParseRanges(t, "AA", "KK"), nil, CardList{0}, 10, rand.NewSource(1))
+	if !errors.Is(err, ErrInvalidCard) {
+		t.Errorf("Expected a zero dead card to be %q, got %v", ErrInvalidCard, err)
+	}
+}
@@ -0,0 +1,215 @@
+package poker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	var tests = map[string]struct {
+		notation string
+		combos   int
+		canon    string
+	}{
+		"Pair":             {"TT", 6, "TT"},
+		"Pairs plus":       {"TT+", 30, "TT+"},
+		"Pair span":        {"TT-77", 24, "TT-77"},
+		"Backward span":    {"77-TT", 24, "TT-77"},
+		"Suited":           {"AKs", 4, "AKs"},
+		"Offsuit":          {"AKo", 12, "AKo"},
+		"Either":           {"AK", 16, "AK"},
+		"Reversed ranks":   {"KAs", 4, "AKs"},
+		"Kickers plus":     {"AQs+", 8, "AQs+"},
+		"Kicker span":      {"A2s-A5s", 16, "A5s-A2s"},
+		"Connectors":       {"76s-54s", 12, "76s-54s"},
+		"Offsuit gappers":  {"T8o, 97o, 86o", 36, "T8o-86o"},
+		"Either connector": {"KQ-JT", 48, "KQ-JT"},
+		"Weighted span":    {"65s:0.5, 54s:0.5, 43s", 12, "65s-54s:0.5, 43s"},
+		"Broken span":      {"76s, 54s", 8, "76s, 54s"},
+		"Kickers first":    {"AKs-KQs, AQs", 12, "AQs+, KQs"},
+		"Exact":            {"AhKh", 1, "AhKh"},
+		"Weighted":         {"AhKh:0.5, QQ:0.25", 7, "QQ:0.25, AhKh:0.5"},
+		"Weighted class":   {"AKs:0.5", 4, "AKs:0.5"},
+		"Mixed weights":    {"AKs:0.5, AKo", 16, "AKs:0.5, AKo"},
+		"Merged":           {"AKs, AKo", 16, "AK"},
+		"Last weight wins": {"TT+, JJ:0.5", 30, "QQ+, JJ:0.5, TT"},
+		"Partial class":    {"AKs, AsKs:0.5", 4, "AKs, AsKs:0.5"},
+		"Override in span": {"TT+, AQs+, KJo, 76s-54s, AhKh:0.5", 62, "TT+, AQs+, KJo, 76s-54s, AhKh:0.5"},
+		"Evenly split":     {"AKs:0.5, AsKs, AhKh", 4, "AKs, AdKd:0.5, AcKc:0.5"},
+		"Whitespace":       {"  TT+ ,AKs  AQo ", 46, "TT+, AKs, AQo"},
+		"Everything":       {"TT+, AQs+, KJo, 76s-54s, AhKd:0.5", 63, "TT+, AQs+, KJo, 76s-54s, AhKd:0.5"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var r, err = ParseRange(tc.notation)
+			if err != nil {
+				t.Fatalf("Unable to parse %q: %s", tc.notation, err)
+			}
+			if r.Len() != tc.combos {
+				t.Errorf("Expected %q to have %d combos, got %d", tc.notation, tc.combos, r.Len())
+			}
+			if r.String() != tc.canon {
+				t.Errorf("Expected %q to be written as %q, got %q", tc.notation, tc.canon, r.String())
+			}
+
+			// Round trip: the canonical notation has to give us the same range
+			var again, _ = ParseRange(r.String())
+			var a, b = r.Combos(), again.Combos()
+			if len(a) != len(b) {
+				t.Fatalf("%q gave %d combos, but its canonical form gave %d", tc.notation, len(a), len(b))
+			}
+			for i := range a {
+				if a[i].Cards.String() != b[i].Cards.String() || a[i].Weight != b[i].Weight {
+					t.Fatalf("%q and its canonical form differ at combo %d: %v vs. %v", tc.notation, i, a[i], b[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseOmahaRange(t *testing.T) {
+	var tests = map[string]struct {
+		notation string
+		combos   int
+		canon    string
+	}{
+		"Two pair":      {"AAKK", 36, "AAKK"},
+		"Rundown":       {"KQJT", 256, "KQJT"},
+		"Five cards":    {"AAKKQ", 144, "AAKKQ"},
+		"Exact":         {"KsKhAsAh", 1, "AsAhKsKh"},
+		"Weighted":      {"AsAhKsKh:0.5", 1, "AsAhKsKh:0.5"},
+		"Mixed weights": {"AAQQ, AAKK:0.5", 72, "AAKK:0.5, AAQQ"},
+		"Partial":       {"JJTT, QQ99, JsJhTsTh:0.25", 72, "QQ99, JsJhTsTh:0.25, JsJhTsTd"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var r, err = ParseOmahaRange(tc.notation)
+			if err != nil {
+				t.Fatalf("Unable to parse %q: %s", tc.notation, err)
+			}
+			if r.Len() != tc.combos {
+				t.Errorf("Expected %q to have %d combos, got %d", tc.notation, tc.combos, r.Len())
+			}
+			if !strings.HasPrefix(r.String(), tc.canon) {
+				t.Errorf("Expected %q to be written as %q, got %q", tc.notation, tc.canon, r.String())
+			}
+
+			var again, _ = ParseOmahaRange(r.String())
+			var a, b = r.Combos(), again.Combos()
+			if len(a) != len(b) {
+				t.Fatalf("%q gave %d combos, but its canonical form gave %d", tc.notation, len(a), len(b))
+			}
+			for i := range a {
+				if a[i].Cards.String() != b[i].Cards.String() || a[i].Weight != b[i].Weight {
+					t.Fatalf("%q and its canonical form differ at combo %d: %v vs. %v", tc.notation, i, a[i], b[i])
+				}
+			}
+		})
+	}
+
+	var r, _ = ParseOmahaRange("JJTT, JsJhTsTh:0.25")
+	if r.Weight(NewCard(Ten, Spades), NewCard(Jack, Hearts), NewCard(Ten, Hearts), NewCard(Jack, Spades)) != 0.25 {
+		t.Errorf("Expected JsJhTsTh to have weight 0.25 in any order")
+	}
+	if r.Size() != 4 {
+		t.Errorf("Expected a size of 4, got %d", r.Size())
+	}
+	if r.Without(CardList{NewCard(Jack, Spades)}).Len() != 18 {
+		t.Errorf("Expected 18 combos without the Js, got %d", r.Without(CardList{NewCard(Jack, Spades)}).Len())
+	}
+}
+
+func TestParseOmahaRangeErrors(t *testing.T) {
+	var tests = []string{
+		"AAK",
+		"AAKKQQJ",
+		"AAAAA",
+		"AsAhKs",
+		"AsAsKsKh",
+		"AAKX",
+		"AAKK:NaN",
+		"AAKK, AAKKQ",
+		"AsAhKsK",
+	}
+
+	for _, notation := range tests {
+		t.Run(notation, func(t *testing.T) {
+			var _, err = ParseOmahaRange(notation)
+			if !errors.Is(err, ErrInvalidRange) {
+				t.Errorf("Expected %q to be %q, got %v", notation, ErrInvalidRange, err)
+			}
+		})
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	var tests = []string{
+		"TTs",
+		"AKx",
+		"A",
+		"AKQ",
+		"1K",
+		"AK:0",
+		"AK:1.5",
+		"AK:abc",
+		"AK:NaN",
+		"AsAs",
+		"AKs-QJo",
+		"AKs-Q2s",
+		"Kh Ah",
+	}
+
+	for _, notation := range tests {
+		t.Run(notation, func(t *testing.T) {
+			var _, err = ParseRange(notation)
+			if !errors.Is(err, ErrInvalidRange) {
+				t.Fatalf("Expected %q to give %q, got %v", notation, ErrInvalidRange, err)
+			}
+		})
+	}
+}
+
+func TestRangeCombos(t *testing.T) {
+	var r, _ = ParseRange("AKs:0.5, QQ")
+	var combos = r.Combos()
+	var expected = []string{"As Ks", "Ah Kh", "Ad Kd", "Ac Kc", "Qs Qh", "Qs Qd", "Qs Qc", "Qh Qd", "Qh Qc", "Qd Qc"}
+	if len(combos) != len(expected) {
+		t.Fatalf("Expected %d combos, got %d", len(expected), len(combos))
+	}
+	for i, c := range combos {
+		if c.Cards.String() != expected[i] {
+			t.Errorf("Expected combo %d to be %q, got %q", i, expected[i], c.Cards)
+		}
+		var w = 1.0
+		if i < 4 {
+			w = 0.5
+		}
+		if c.Weight != w {
+			t.Errorf("Expected %s to have weight %g, got %g", c.Cards, w, c.Weight)
+		}
+	}
+
+	var ah, kh = newCardString("Ah"), newCardString("Kh")
+	if r.Weight(kh, ah) != 0.5 {
+		t.Errorf("Expected Kh Ah to have weight 0.5, got %g", r.Weight(kh, ah))
+	}
+}
+
+func TestRangeWithout(t *testing.T) {
+	var r, _ = ParseRange("AA, AKs")
+	var dead, _ = ParseCards("As 2c")
+	var live = r.Without(dead)
+
+	if live.Len() != 6 {
+		t.Fatalf("Expected 6 combos without the ace of spades, got %d", live.Len())
+	}
+	if live.String() != "AhAd, AhAc, AdAc, AhKh, AdKd, AcKc" {
+		t.Errorf("Got unexpected range %q", live.String())
+	}
+	if r.Len() != 10 {
+		t.Errorf("Without changed the original range: it has %d combos", r.Len())
+	}
+}
@@ -0,0 +1,79 @@
+package poker
+
+import "fmt"
+
+// Scoring identifies the rules used to score a hand. No matter the rules, a
+// lower score is always a better hand.
+type Scoring int
+
+// All supported ways to score a hand
+const (
+	// HighScoring is standard poker: the best high hand wins
+	HighScoring Scoring = iota
+	// AceToFiveLow is lowball where aces are low and straights and flushes
+	// don't count, as in Razz or California lowball
+	AceToFiveLow
+	// DeuceToSevenLow is lowball where aces are high and straights and flushes
+	// count against you, as in Kansas City lowball or 2-7 triple draw
+	DeuceToSevenLow
+	// ShortDeckHigh is high-hand poker with sixes through aces only, where a
+	// flush beats a full house and A-6-7-8-9 is a straight
+	ShortDeckHigh
+)
+
+func (s Scoring) String() string {
+	switch s {
+	case HighScoring:
+		return "High"
+	case AceToFiveLow:
+		return "Ace-To-Five Low"
+	case DeuceToSevenLow:
+		return "Deuce-To-Seven Low"
+	case ShortDeckHigh:
+		return "Short-Deck High"
+	}
+
+	return ""
+}
+
+// fiveCardEval returns the five-card evaluator for these rules
+func (s Scoring) fiveCardEval() fiveCardEval {
+	switch s {
+	case AceToFiveLow:
+		return evalAceToFive
+	case DeuceToSevenLow:
+		return evalDeuceToSeven
+	case ShortDeckHigh:
+		return evalShortDeck
+	}
+	return evalFiveFast
+}
+
+// handRank converts a score from these rules into its HandRank
+func (s Scoring) handRank(v uint16) HandRank {
+	switch s {
+	case AceToFiveLow:
+		return GetAceToFiveHandRank(v)
+	case DeuceToSevenLow:
+		return GetDeuceToSevenHandRank(v)
+	case ShortDeckHigh:
+		return GetShortDeckHandRank(v)
+	}
+	return GetHandRank(v)
+}
+
+// checkCards returns an error if any card can't be used under these rules
+func (s Scoring) checkCards(lists ...CardList) error {
+	if s != ShortDeckHigh {
+		return nil
+	}
+
+	for _, cl := range lists {
+		for _, c := range cl {
+			if c.Rank() < Six {
+				return fmt.Errorf("%w: %s under %s rules", ErrInvalidCard, c, s)
+			}
+		}
+	}
+	return nil
+}
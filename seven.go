@@ -0,0 +1,192 @@
+package poker
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// rankKeys are added together for each card in a seven-card hand to get a
+// number which is unique to that hand's combination of ranks. The values come
+// from the SKPokerEval project, and are only collision-free when exactly seven
+// cards are summed.
+var rankKeys = [13]uint32{0, 1, 5, 22, 98, 453, 2031, 8698, 22854, 83661, 262349, 636345, 1479181}
+
+// The largest possible rank key sum: four aces and three kings
+const maxRankKey = 4*1479181 + 3*636345
+
+// suitCounters turns a card's suit bits into a value which counts that suit
+// in its own four-bit nibble when summed with other cards' counters
+var suitCounters = [16]uint32{1: 1 << 0, 2: 1 << 4, 4: 1 << 8, 8: 1 << 12}
+
+// sevenCardTableMagic prefixes a serialized SevenCardTable so garbage input
+// can be caught early
+const sevenCardTableMagic = "NMP7"
+
+// SevenCardTable is a precomputed lookup structure for scoring seven-card
+// hands without brute-forcing all twenty-one five-card permutations. Scores
+// are identical to what CardList.Evaluate returns, so GetHandRank and friends
+// work as usual.
+//
+// A table takes about 16 megs of memory, and building one takes a noticeable
+// fraction of a second, so it should be created once and reused. It's safe
+// for concurrent use once built. For the fastest startup, build it once and
+// save it with WriteTo, then load it on future runs with ReadSevenCardTable.
+type SevenCardTable struct {
+	flush []uint16
+	ranks []uint16
+}
+
+// NewSevenCardTable computes and returns a new seven-card lookup table
+func NewSevenCardTable() *SevenCardTable {
+	var t = &SevenCardTable{
+		flush: make([]uint16, 1<<13),
+		ranks: make([]uint16, maxRankKey+1),
+	}
+	t.buildFlushes()
+	t.buildRanks()
+	return t
+}
+
+// buildFlushes stores the best flush (or straight flush) for every set of
+// five to seven ranks within a single suit
+func (t *SevenCardTable) buildFlushes() {
+	var cards = make(CardList, 0, 7)
+	for mask := 0; mask < len(t.flush); mask++ {
+		cards = cards[:0]
+		for r := Deuce; r <= Ace; r++ {
+			if mask&(1<<r) != 0 {
+				cards = append(cards, NewCard(r, Spades))
+			}
+		}
+		if len(cards) >= 5 && len(cards) <= 7 {
+			t.flush[mask] = cards.Evaluate()
+		}
+	}
+}
+
+// buildRanks walks every combination of seven ranks (no more than four of any
+// one rank) and stores the best non-flush score for each
+func (t *SevenCardTable) buildRanks() {
+	var counts [13]int
+	var cards = make(CardList, 7)
+	var build func(r CardRank, left int)
+	build = func(r CardRank, left int) {
+		if r > Ace {
+			if left != 0 {
+				return
+			}
+
+			// Suits are dealt round-robin so no suit can have more than two cards,
+			// and no rank can have the same suit twice
+			var idx, key = 0, uint32(0)
+			for rank, n := range counts {
+				for i := 0; i < n; i++ {
+					cards[idx] = NewCard(CardRank(rank), CardSuit(1<<(idx%4)))
+					key += rankKeys[rank]
+					idx++
+				}
+			}
+			t.ranks[key] = cards.evalMore()
+			return
+		}
+
+		for n := 0; n <= 4 && n <= left; n++ {
+			counts[r] = n
+			build(r+1, left-n)
+		}
+		counts[r] = 0
+	}
+	build(Deuce, 7)
+}
+
+// Evaluate returns the score for the best five-card hand within the given
+// seven cards. Any other number of cards returns math.MaxUint16.
+func (t *SevenCardTable) Evaluate(cl CardList) uint16 {
+	if len(cl) != 7 {
+		return math.MaxUint16
+	}
+	return t.eval7(cl[0], cl[1], cl[2], cl[3], cl[4], cl[5], cl[6])
+}
+
+func (t *SevenCardTable) eval7(c1, c2, c3, c4, c5, c6, c7 Card) uint16 {
+	var suits = suitCounters[(c1>>12)&0xf] + suitCounters[(c2>>12)&0xf] +
+		suitCounters[(c3>>12)&0xf] + suitCounters[(c4>>12)&0xf] +
+		suitCounters[(c5>>12)&0xf] + suitCounters[(c6>>12)&0xf] +
+		suitCounters[(c7>>12)&0xf]
+
+	// Adding three to each nibble pushes counts of five or more into the
+	// nibble's high bit. With seven cards, at most one suit can get there.
+	var flush = (suits + 0x3333) & 0x8888
+	if flush != 0 {
+		var suit = Card(flush>>3) & 0x1111
+		suit = (suit | suit>>3 | suit>>6 | suit>>9) & 0xf
+		var mask Card
+		for _, c := range [7]Card{c1, c2, c3, c4, c5, c6, c7} {
+			if (c>>12)&0xf == suit {
+				mask |= c >> 16
+			}
+		}
+		return t.flush[mask]
+	}
+
+	var key = rankKeys[(c1>>8)&0xf] + rankKeys[(c2>>8)&0xf] + rankKeys[(c3>>8)&0xf] +
+		rankKeys[(c4>>8)&0xf] + rankKeys[(c5>>8)&0xf] + rankKeys[(c6>>8)&0xf] +
+		rankKeys[(c7>>8)&0xf]
+	return t.ranks[key]
+}
+
+// WriteTo implements io.WriterTo, serializing the table so it can be loaded
+// later with ReadSevenCardTable instead of being rebuilt
+func (t *SevenCardTable) WriteTo(w io.Writer) (n int64, err error) {
+	var cw = &countingWriter{w: w}
+	_, err = io.WriteString(cw, sevenCardTableMagic)
+	for _, data := range [][]uint16{t.flush, t.ranks} {
+		if err != nil {
+			break
+		}
+		err = binary.Write(cw, binary.LittleEndian, data)
+	}
+
+	return cw.n, err
+}
+
+// ReadSevenCardTable loads a table previously saved with
+// SevenCardTable.WriteTo
+func ReadSevenCardTable(r io.Reader) (*SevenCardTable, error) {
+	var magic = make([]byte, len(sevenCardTableMagic))
+	var _, err = io.ReadFull(r, magic)
+	if err != nil {
+		return nil, fmt.Errorf("ReadSevenCardTable(): %w", err)
+	}
+	if string(magic) != sevenCardTableMagic {
+		return nil, fmt.Errorf("ReadSevenCardTable(): %w", ErrInvalidTableData)
+	}
+
+	var t = &SevenCardTable{
+		flush: make([]uint16, 1<<13),
+		ranks: make([]uint16, maxRankKey+1),
+	}
+	for _, data := range [][]uint16{t.flush, t.ranks} {
+		err = binary.Read(r, binary.LittleEndian, data)
+		if err != nil {
+			return nil, fmt.Errorf("ReadSevenCardTable(): %w", err)
+		}
+	}
+
+	return t, nil
+}
+
+// countingWriter wraps an io.Writer to keep track of the bytes written for
+// io.WriterTo implementations
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	var n, err = cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
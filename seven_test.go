@@ -0,0 +1,123 @@
+package poker
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+var sevenTable *SevenCardTable
+var sevenTableOnce sync.Once
+
+// getSevenTable builds the lookup table just once no matter how many tests
+// and benchmarks need it
+func getSevenTable() *SevenCardTable {
+	sevenTableOnce.Do(func() {
+		sevenTable = NewSevenCardTable()
+	})
+	return sevenTable
+}
+
+func TestSevenCardTableEvaluate(t *testing.T) {
+	var table = getSevenTable()
+	var deck = NewDeck(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		deck.Reset()
+		deck.Shuffle()
+		var hand = deck.Draw(7)
+
+		var want = hand.Evaluate()
+		var got = table.Evaluate(hand)
+		if got != want {
+			t.Fatalf("%s: table gave %d, but Evaluate gave %d", hand, got, want)
+		}
+	}
+}
+
+func TestSevenCardTableKnownHands(t *testing.T) {
+	var table = getSevenTable()
+	var tests = map[string]struct {
+		hand string
+		want uint16
+	}{
+		"Ace high":         {"2d 3d As Ks Jc 7h 5d", 6252},
+		"Flush":            {"2d 3d Ts 7s 4s 3s 2s", 1542},
+		"Full house":       {"2d 3d 4s 4c 4d 2s 2h", 298},
+		"Straight flush":   {"2d 3d As Ks Qs Js Ts", 1},
+		"Seven-card wheel": {"As 2d 3c 4h 5s Kd Kc", 1609},
+		"Wheel flush":      {"As 2s 3s 4s 5s Ks Qs", 10},
+		"Quads over trips": {"9s 9h 9d 9c 2s 2h 2d", 82},
+		"Six cards":        {"As Ks Qs Js Ts 9s", math.MaxUint16},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cards, err = ParseCards(tc.hand)
+			if err != nil {
+				t.Fatalf("Unable to parse %q: %s", tc.hand, err)
+			}
+			var got = table.Evaluate(cards)
+			if got != tc.want {
+				t.Fatalf("%s gave %d; expected %d", cards, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSevenCardTableReadWrite(t *testing.T) {
+	var table = getSevenTable()
+	var buf bytes.Buffer
+	var n, err = table.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("Unable to write table: %s", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("WriteTo reported %d bytes, but wrote %d", n, buf.Len())
+	}
+
+	var loaded *SevenCardTable
+	loaded, err = ReadSevenCardTable(&buf)
+	if err != nil {
+		t.Fatalf("Unable to read table: %s", err)
+	}
+	if !equalUint16s(loaded.flush, table.flush) || !equalUint16s(loaded.ranks, table.ranks) {
+		t.Fatalf("Loaded table doesn't match the original")
+	}
+
+	_, err = ReadSevenCardTable(bytes.NewBufferString("junk data"))
+	if err == nil {
+		t.Fatalf("Expected an error reading junk data, got nil")
+	}
+}
+
+func equalUint16s(a, b []uint16) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func BenchmarkSevenCardTable(b *testing.B) {
+	var table = getSevenTable()
+	var deck *Deck
+	var hands = make([]CardList, 100)
+	for i := 0; i < 100; i++ {
+		deck = NewDeck(rand.NewSource(int64(i)))
+		deck.Shuffle()
+		hands[i] = deck.Draw(7)
+	}
+
+	b.ResetTimer()
+	var hl = len(hands)
+	for i := 0; i < b.N; i++ {
+		var hand = hands[i%hl]
+		table.Evaluate(hand)
+	}
+}
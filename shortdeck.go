@@ -0,0 +1,85 @@
+package poker
+
+import "math"
+
+// shortDeckWheel holds the rank bits for A-6-7-8-9, the lowest straight in
+// short-deck poker
+const shortDeckWheel = 1<<Ace | 1<<Six | 1<<Seven | 1<<Eight | 1<<Nine
+
+// evalShortDeck scores five cards for short-deck (6+) Hold 'em, where a flush
+// beats a full house and A-6-7-8-9 is a straight. Scores use the same range as
+// high hands, so most hands score exactly what evalFiveFast gives them:
+//
+//   - The wheel takes the place of the nine-high straight (flush), which
+//     can't exist without a five
+//   - Flushes and full houses trade places, so flushes are 167-1443 and full
+//     houses are 1444-1599
+func evalShortDeck(c1, c2, c3, c4, c5 Card) uint16 {
+	var h = evalFiveFast(c1, c2, c3, c4, c5)
+	if (c1|c2|c3|c4|c5)>>16 == shortDeckWheel {
+		if (c1 & c2 & c3 & c4 & c5 & 0xf000) != 0 {
+			return 6
+		}
+		return 1605
+	}
+
+	if h > 322 && h < 1600 {
+		return h - 156
+	}
+	if h > 166 && h < 323 {
+		return h + 1277
+	}
+	return h
+}
+
+// GetShortDeckHandRank converts a short-deck hand value into the rank it
+// represents. Note that in short-deck, a Flush beats a FullHouse, so the
+// HandRank values alone can't be used to compare hands.
+func GetShortDeckHandRank(v uint16) HandRank {
+	if v > 6185 {
+		return HighCard
+	}
+	if v > 3325 {
+		return OnePair
+	}
+	if v > 2467 {
+		return TwoPair
+	}
+	if v > 1609 {
+		return ThreeOfAKind
+	}
+	if v > 1599 {
+		return Straight
+	}
+	if v > 1443 {
+		return FullHouse
+	}
+	if v > 166 {
+		return Flush
+	}
+	if v > 10 {
+		return FourOfAKind
+	}
+	return StraightFlush
+}
+
+// EvaluateShortDeck returns the score for the best five-card short-deck hand
+// found. The lower the score, the better the hand.
+//
+// Hands can be 5, 6, or 7 cards, otherwise the return will be math.MaxUint16.
+// Cards below a six aren't part of a short deck, and will give meaningless
+// results.
+func (cl CardList) EvaluateShortDeck() uint16 {
+	if len(cl) < 5 || len(cl) > 7 {
+		return math.MaxUint16
+	}
+
+	var score, _ = cl.bestHandWith(evalShortDeck)
+	return score
+}
+
+// BestShortDeckHand returns the same score as EvaluateShortDeck, but also the
+// five cards that made up the best hand
+func (cl CardList) BestShortDeckHand() (score uint16, best [5]Card) {
+	return cl.bestHandWith(evalShortDeck)
+}
@@ -0,0 +1,99 @@
+package poker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEvaluateShortDeck(t *testing.T) {
+	var tests = map[string]struct {
+		hand  string
+		score uint16
+		rank  HandRank
+	}{
+		"Royal flush":                     {"As Ks Qs Js Ts", 1, StraightFlush},
+		"Wheel straight flush":            {"As 6s 7s 8s 9s", 6, StraightFlush},
+		"Four of a kind":                  {"As Ac Ad Ah 6h", 18, FourOfAKind},
+		"Best flush":                      {"As Ks Qs Js 9s", 167, Flush},
+		"Lowest non-wheel straight flush": {"Ts 9s 8s 7s 6s", 5, StraightFlush},
+		"Ace-jack flush":                  {"As Js 9s 8s 7s", 479, Flush},
+		"Best full house":                 {"As Ac Ad Kh Ks", 1444, FullHouse},
+		"Sixes full":                      {"6s 6c 6d 7h 7s", 1547, FullHouse},
+		"Broadway":                        {"As Kd Qc Jh Ts", 1600, Straight},
+		"Wheel straight":                  {"As 6d 7c 8h 9s", 1605, Straight},
+		"Three of a kind":                 {"As Ac Ad Jd 6d", 1635, ThreeOfAKind},
+		"One pair":                        {"As Ac Jc 7h 6d", 3447, OnePair},
+		"Six cards":                       {"As Kd 6d 7c 8h 9s", 1605, Straight},
+		"Seven cards":                     {"As 6d 7c 8h 9s Td Ad", 1604, Straight},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cards, err = ParseCards(tc.hand)
+			if err != nil {
+				t.Fatalf("Unable to parse %q: %s", tc.hand, err)
+			}
+
+			var got = cards.EvaluateShortDeck()
+			if got != tc.score {
+				t.Fatalf("%s gave a short-deck score of %d; expected %d", cards, got, tc.score)
+			}
+			var rank = GetShortDeckHandRank(got)
+			if rank != tc.rank {
+				t.Fatalf("%s gave a short-deck rank of %s; expected %s", cards, rank, tc.rank)
+			}
+		})
+	}
+}
+
+func TestShortDeckFlushBeatsFullHouse(t *testing.T) {
+	var flush, _ = ParseCards("Ts 9s 8s 6s Js")
+	var boat, _ = ParseCards("As Ac Ad Kh Ks")
+	if flush.EvaluateShortDeck() >= boat.EvaluateShortDeck() {
+		t.Fatalf("Expected %s to beat %s", flush, boat)
+	}
+	if flush.Evaluate() <= boat.Evaluate() {
+		t.Fatalf("Expected %s to lose to %s in standard poker", flush, boat)
+	}
+}
+
+func TestHandEvaluateShortDeck(t *testing.T) {
+	var tests = map[string]struct {
+		hole string
+		comm string
+		best string
+		desc string
+	}{
+		"Wheel":           {"As 6d", "7c 8h 9s Kd Kc", "9s 8h 7c 6d As", "Nine-High Straight"},
+		"Wheel flush":     {"As 6s", "7s 8s 9s Kd Kc", "9s 8s 7s 6s As", "Nine-High Straight Flush"},
+		"Flush over boat": {"Ks Kd", "Kc 8s 9s 6s Js", "Ks Js 9s 8s 6s", "King-High Flush"},
+		"Full house":      {"Ks Kd", "Kc 8s 8d 6h Js", "Kc Kd Ks 8d 8s", "Full House, Kings Over Eights"},
+		"Broadway":        {"As Kd", "Qc Jh Ts 6d 6c", "As Kd Qc Jh Ts", "Ace-High Straight"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var hand, _ = makeHand(tc.hole)
+			var comm, _ = ParseCards(tc.comm)
+			var res, err = hand.EvaluateAs(ShortDeckHigh, comm...)
+			if err != nil {
+				t.Fatalf("Unable to evaluate %q / %q: %s", tc.hole, tc.comm, err)
+			}
+			if res.Best5.String() != tc.best {
+				t.Errorf("Expected best hand %q, got %q", tc.best, res.Best5)
+			}
+			if res.Describe() != tc.desc {
+				t.Errorf("Expected description %q, got %q", tc.desc, res.Describe())
+			}
+		})
+	}
+}
+
+func TestHandEvaluateShortDeckInvalidCard(t *testing.T) {
+	var hand, _ = makeHand("As 5d")
+	var comm, _ = ParseCards("7c 8h 9s Kd Kc")
+	var _, err = hand.EvaluateAs(ShortDeckHigh, comm...)
+	if !errors.Is(err, ErrInvalidCard) {
+		t.Fatalf("Expected ErrInvalidCard using a five in short-deck, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package poker
+
+import "fmt"
+
+// StartingHand is one of the 169 distinct two-card starting hands in Texas
+// Hold 'em, such as "AKs", "T9o", or "77". Its value is the hand's position
+// in the usual 13x13 grid, reading left to right and top to bottom with aces
+// first: pairs run down the diagonal, suited hands are above it, and offsuit
+// hands are below it. So AA is 0, AKs is 1, AKo is 13, and 22 is 168.
+type StartingHand int
+
+// NumStartingHands is the number of distinct starting hands
+const NumStartingHands = 169
+
+// NewStartingHand returns the starting hand for exactly two hole cards
+func NewStartingHand(cards CardList) (StartingHand, error) {
+	if len(cards) != 2 {
+		return 0, fmt.Errorf("%w: a starting hand needs exactly two cards", ErrInvalidCardCount)
+	}
+	if cards[0] == cards[1] {
+		return 0, fmt.Errorf("%w: %s", ErrDuplicateCard, cards[0])
+	}
+
+	var hi, lo = cards[0].Rank(), cards[1].Rank()
+	if lo > hi {
+		hi, lo = lo, hi
+	}
+
+	// Cards share a suit bit only when they're suited
+	var suited = cards[0]&cards[1]&0xf000 != 0
+	return newStartingHand(hi, lo, suited), nil
+}
+
+// newStartingHand returns the grid position for the given ranks, where hi is
+// at least as high as lo
+func newStartingHand(hi, lo CardRank, suited bool) StartingHand {
+	var row, col = int(Ace - hi), int(Ace - lo)
+	if !suited {
+		row, col = col, row
+	}
+	return StartingHand(row*13 + col)
+}
+
+// ParseStartingHand converts notation like "AKs", "T9o", or "77" into a
+// StartingHand. The ranks may be in either order, but two different ranks
+// must be followed by "s" or "o".
+func ParseStartingHand(s string) (StartingHand, error) {
+	var hi, lo, kind, err = parseHandClass(s)
+	if err != nil || kind == kindAny {
+		return 0, fmt.Errorf("%w: %q is not a starting hand", ErrInvalidRange, s)
+	}
+	return newStartingHand(hi, lo, kind == kindSuited), nil
+}
+
+// Ranks returns the hand's two ranks, the higher one first
+func (sh StartingHand) Ranks() (hi, lo CardRank) {
+	var row, col = int(sh) / 13, int(sh) % 13
+	if row > col {
+		row, col = col, row
+	}
+	return Ace - CardRank(row), Ace - CardRank(col)
+}
+
+// Pair returns true if both cards have the same rank
+func (sh StartingHand) Pair() bool {
+	return int(sh)/13 == int(sh)%13
+}
+
+// Suited returns true if the cards share a suit
+func (sh StartingHand) Suited() bool {
+	return int(sh)/13 < int(sh)%13
+}
+
+// ComboCount returns how many ways the hand can be dealt: 6 for a pair, 4 for
+// a suited hand, and 12 for an offsuit hand
+func (sh StartingHand) ComboCount() int {
+	switch {
+	case sh.Pair():
+		return 6
+	case sh.Suited():
+		return 4
+	}
+	return 12
+}
+
+// Valid returns true if the value is one of the 169 starting hands
+func (sh StartingHand) Valid() bool {
+	return sh >= 0 && sh < NumStartingHands
+}
+
+// String returns the hand's usual short name, e.g., "AKs", "T9o", or "77"
+func (sh StartingHand) String() string {
+	if !sh.Valid() {
+		return ""
+	}
+
+	var hi, lo = sh.Ranks()
+	var s = hi.String() + lo.String()
+	switch {
+	case sh.Pair():
+		return s
+	case sh.Suited():
+		return s + "s"
+	}
+	return s + "o"
+}
@@ -0,0 +1,102 @@
+package poker
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestNewStartingHand(t *testing.T) {
+	var tests = map[string]struct {
+		cards  string
+		name   string
+		index  int
+		combos int
+	}{
+		"Aces":              {"As Ah", "AA", 0, 6},
+		"Big slick":         {"Ah Kh", "AKs", 1, 4},
+		"Big slick, off":    {"Ah Kd", "AKo", 13, 12},
+		"Kings":             {"Kc Kd", "KK", 14, 6},
+		"Backwards":         {"Kh Ah", "AKs", 1, 4},
+		"Suited connector":  {"9c Tc", "T9s", 4*13 + 5, 4},
+		"Offsuit connector": {"Td 9c", "T9o", 5*13 + 4, 12},
+		"Sevens":            {"7s 7c", "77", 7*13 + 7, 6},
+		"Worst offsuit":     {"3d 2c", "32o", 12*13 + 11, 12},
+		"Deuces":            {"2d 2c", "22", 168, 6},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cards, _ = ParseCards(tc.cards)
+			var sh, err = NewStartingHand(cards)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if sh.String() != tc.name {
+				t.Errorf("Expected %s to be %q, got %q", cards, tc.name, sh)
+			}
+			if int(sh) != tc.index {
+				t.Errorf("Expected %s to have index %d, got %d", cards, tc.index, sh)
+			}
+			if sh.ComboCount() != tc.combos {
+				t.Errorf("Expected %s to have %d combos, got %d", cards, tc.combos, sh.ComboCount())
+			}
+
+			var parsed, _ = ParseStartingHand(tc.name)
+			if parsed != sh {
+				t.Errorf("Expected %q to parse as %d, got %d", tc.name, sh, parsed)
+			}
+		})
+	}
+}
+
+func TestStartingHandsCoverAllCombos(t *testing.T) {
+	var deck = NewDeck(rand.NewSource(0)).cards
+	var counts = make(map[StartingHand]int)
+	for a := 0; a < 52; a++ {
+		for b := a + 1; b < 52; b++ {
+			var sh, _ = NewStartingHand(CardList{deck[a], deck[b]})
+			counts[sh]++
+		}
+	}
+
+	if len(counts) != NumStartingHands {
+		t.Fatalf("Expected %d starting hands, got %d", NumStartingHands, len(counts))
+	}
+	for sh := StartingHand(0); sh < NumStartingHands; sh++ {
+		if counts[sh] != sh.ComboCount() {
+			t.Errorf("%s: expected %d combos, but %d were dealt", sh, sh.ComboCount(), counts[sh])
+		}
+
+		// Every name has to parse back to the same hand
+		var parsed, err = ParseStartingHand(sh.String())
+		if err != nil || parsed != sh {
+			t.Errorf("%s (%d) parsed as %d, %v", sh, int(sh), parsed, err)
+		}
+	}
+}
+
+func TestStartingHandErrors(t *testing.T) {
+	var cards, _ = ParseCards("As Ks Qs")
+	var _, err = NewStartingHand(cards)
+	if !errors.Is(err, ErrInvalidCardCount) {
+		t.Errorf("Expected three cards to be %q, got %v", ErrInvalidCardCount, err)
+	}
+
+	cards, _ = ParseCards("As As")
+	_, err = NewStartingHand(cards)
+	if !errors.Is(err, ErrDuplicateCard) {
+		t.Errorf("Expected a repeated card to be %q, got %v", ErrDuplicateCard, err)
+	}
+
+	for _, s := range []string{"AK", "AAs", "XKs", "A"} {
+		_, err = ParseStartingHand(s)
+		if !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("Expected %q to be %q, got %v", s, ErrInvalidRange, err)
+		}
+	}
+
+	if StartingHand(NumStartingHands).String() != "" {
+		t.Errorf("Expected an invalid starting hand to have no name")
+	}
+}
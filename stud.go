@@ -0,0 +1,308 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// StudStreet is one round of dealing in a seven-card stud hand. Its value is
+// how many cards each player holds once the street is dealt.
+type StudStreet int
+
+// All stud streets, in order
+const (
+	ThirdStreet StudStreet = iota + 3
+	FourthStreet
+	FifthStreet
+	SixthStreet
+	SeventhStreet
+)
+
+func (s StudStreet) String() string {
+	switch s {
+	case ThirdStreet:
+		return "Third Street"
+	case FourthStreet:
+		return "Fourth Street"
+	case FifthStreet:
+		return "Fifth Street"
+	case SixthStreet:
+		return "Sixth Street"
+	case SeventhStreet:
+		return "Seventh Street"
+	}
+
+	return ""
+}
+
+// maxStudPlayers is as many players as can play stud from one deck. Eight
+// players who all stay to seventh street need a community card.
+const maxStudPlayers = 8
+
+// StudPlayer is one player's cards in a stud hand: Down cards are seen only
+// by the player, and Up cards by everybody
+type StudPlayer struct {
+	Down   CardList
+	Up     CardList
+	Folded bool
+}
+
+// Cards returns all of the player's own cards, down cards first
+func (p StudPlayer) Cards() CardList {
+	var cards = make(CardList, 0, len(p.Down)+len(p.Up))
+	cards = append(cards, p.Down...)
+	return append(cards, p.Up...)
+}
+
+// Stud deals seven-card stud: two down cards and one up card on third
+// street, an up card on each of the next three streets, and a last down card
+// on seventh street. It decides who brings in the betting and who acts first
+// on each street, but leaves the betting itself to the caller; StudTable
+// deals with a Stud and runs the betting, too.
+//
+// Players are numbered from the dealer's left. Stud doesn't burn cards, so
+// the deck only runs short when eight players all stay to seventh street.
+// Then one card is turned face up in the middle for everybody to share.
+type Stud struct {
+	deck      *Deck
+	players   []*StudPlayer
+	street    StudStreet
+	community CardList
+}
+
+// NewStud returns a stud game for two to eight players, ready to deal the
+// first hand
+func NewStud(numPlayers int, rndSource rand.Source) (*Stud, error) {
+	if numPlayers < 2 || numPlayers > maxStudPlayers {
+		return nil, fmt.Errorf("%w: stud needs 2 to %d players", ErrInvalidPlayerCount, maxStudPlayers)
+	}
+
+	var s = &Stud{deck: NewDeck(rndSource), players: make([]*StudPlayer, numPlayers)}
+	s.Reset()
+	return s, nil
+}
+
+// Reset gathers up the cards and shuffles them for a new hand
+func (s *Stud) Reset() {
+	for i := range s.players {
+		s.players[i] = &StudPlayer{}
+	}
+	s.street = 0
+	s.community = nil
+	s.deck.Reset()
+	s.deck.Shuffle()
+}
+
+// NumPlayers returns how many players are in the game
+func (s *Stud) NumPlayers() int {
+	return len(s.players)
+}
+
+// Player returns a copy of the given player's cards
+func (s *Stud) Player(i int) StudPlayer {
+	return *s.players[i]
+}
+
+// Street returns the last street dealt, or zero if the hand hasn't started
+func (s *Stud) Street() StudStreet {
+	return s.street
+}
+
+// Community returns a copy of the shared card, if the deck ran short on
+// seventh street
+func (s *Stud) Community() CardList {
+	return append(CardList(nil), s.community...)
+}
+
+// Active returns how many players haven't folded
+func (s *Stud) Active() int {
+	var n int
+	for _, p := range s.players {
+		if !p.Folded {
+			n++
+		}
+	}
+	return n
+}
+
+// Fold takes a player out of the hand. They aren't dealt any more cards, and
+// their up cards no longer decide who acts first.
+func (s *Stud) Fold(i int) error {
+	if i < 0 || i >= len(s.players) {
+		return fmt.Errorf("%w: %d", ErrInvalidSeat, i)
+	}
+	if s.street == 0 {
+		return ErrNoHandInProgress
+	}
+	if s.players[i].Folded || s.Active() < 2 {
+		return fmt.Errorf("%w: player %d can't fold", ErrIllegalAction, i)
+	}
+	s.players[i].Folded = true
+	return nil
+}
+
+// Deal deals the next street to every player who hasn't folded, starting
+// with third street
+func (s *Stud) Deal() error {
+	if s.street == SeventhStreet {
+		return fmt.Errorf("%w: every street has been dealt", ErrIllegalAction)
+	}
+	if s.street != 0 && s.Active() < 2 {
+		return fmt.Errorf("%w: only one player is left", ErrIllegalAction)
+	}
+
+	if s.street == 0 {
+		s.street = ThirdStreet
+		for _, up := range []bool{false, false, true} {
+			var err = s.dealRound(up)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	s.street++
+	if s.street != SeventhStreet {
+		return s.dealRound(true)
+	}
+	if s.deck.Count() < s.Active() {
+		return s.deck.Deal(&s.community)
+	}
+	return s.dealRound(false)
+}
+
+// dealRound deals one card, up or down, to each player still in
+func (s *Stud) dealRound(up bool) error {
+	for _, p := range s.players {
+		if p.Folded {
+			continue
+		}
+
+		var to = &p.Down
+		if up {
+			to = &p.Up
+		}
+		var err = s.deck.Deal(to)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BringIn returns the player with the lowest up card on third street, who
+// has to start the betting. Aces are high, and suits break ties from lowest
+// to highest: clubs, diamonds, hearts, then spades. -1 is returned if third
+// street hasn't been dealt.
+func (s *Stud) BringIn() int {
+	if s.street == 0 {
+		return -1
+	}
+
+	var low = -1
+	for i, p := range s.players {
+		if p.Folded {
+			continue
+		}
+		if low < 0 || p.Up[0].precedence() < s.players[low].Up[0].precedence() {
+			low = i
+		}
+	}
+	return low
+}
+
+// FirstToAct returns who starts the betting on the current street: the
+// bring-in on third street, or the best showing hand on later streets. Only
+// pairs, two pair, trips, and quads count in a showing hand, not straights
+// or flushes. If two players show the same hand, the one closest to the
+// dealer's left goes first. -1 is returned if the hand hasn't started.
+func (s *Stud) FirstToAct() int {
+	if s.street <= ThirdStreet {
+		return s.BringIn()
+	}
+
+	var best = -1
+	var bestShowing []int
+	for i, p := range s.players {
+		if p.Folded {
+			continue
+		}
+		var showing = showingValue(p.Up)
+		if best < 0 || compareShowing(showing, bestShowing) > 0 {
+			best, bestShowing = i, showing
+		}
+	}
+	return best
+}
+
+// showingValue ranks up cards for deciding who acts first: how many cards
+// are in the biggest group (four of a kind down to a single card), then
+// whether there's a second pair, then the ranks of each group, biggest groups
+// first and highest ranks first within groups of the same size
+func showingValue(up CardList) []int {
+	var counts [13]int
+	for _, c := range up {
+		counts[c.Rank()]++
+	}
+
+	var ranks []int
+	for r := range counts {
+		if counts[r] > 0 {
+			ranks = append(ranks, r)
+		}
+	}
+	sort.Slice(ranks, func(a, b int) bool {
+		if counts[ranks[a]] != counts[ranks[b]] {
+			return counts[ranks[a]] > counts[ranks[b]]
+		}
+		return ranks[a] > ranks[b]
+	})
+
+	var twoPair int
+	if len(ranks) > 1 && counts[ranks[1]] == 2 {
+		twoPair = 1
+	}
+	return append([]int{counts[ranks[0]], twoPair}, ranks...)
+}
+
+// compareShowing returns a positive number if showing hand a is better than
+// b, negative if it's worse, and zero if they're the same
+func compareShowing(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return len(a) - len(b)
+}
+
+// Results evaluates every hand still in once seventh street is dealt, or as
+// soon as everybody else folds. Results are indexed by player, with nil for
+// players who folded; pair them with Winners or, for chips, Distribute and
+// OddChipBySuit. If everybody else folded, the last player's result is nil,
+// too, since they don't have to show.
+func (s *Stud) Results() ([]*HandResult, error) {
+	var results = make([]*HandResult, len(s.players))
+	if s.street != 0 && s.Active() == 1 {
+		return results, nil
+	}
+	if s.street != SeventhStreet {
+		return nil, fmt.Errorf("%w: the hand isn't over", ErrIllegalAction)
+	}
+
+	for i, p := range s.players {
+		if p.Folded {
+			continue
+		}
+		// The community card plays like one of the player's own, not like a
+		// Hold 'em board card
+		var hr, err = NewHand(append(p.Cards(), s.community...)).Evaluate()
+		if err != nil {
+			return nil, err
+		}
+		results[i] = hr
+	}
+	return results, nil
+}
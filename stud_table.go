@@ -0,0 +1,212 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// StudTable runs seven-card stud hands with chips: everybody antes, the
+// lowest up card brings it in, and the best showing hand starts the betting
+// on each later street. It bets by the same rules as a Table, and settles the
+// pots with odd chips going by suit.
+//
+// StudTables start out as fixed limit, with small bets on third and fourth
+// street and big bets from fifth street on. The bring-in is posted for the
+// player automatically, like a blind; the next player can call it or
+// complete it to a full small bet.
+//
+// A StudTable is *not* safe for concurrent use.
+type StudTable struct {
+	bettingTable
+
+	ante      int
+	bringIn   int
+	rndSource rand.Source
+
+	// stud deals the current hand, and players maps its player numbers to
+	// seats
+	stud    *Stud
+	players []int
+}
+
+// NewStudTable returns an empty stud table with the given number of seats
+// (two to eight), ante, bring-in, and fixed-limit bets. The ante may be zero,
+// but the bring-in can't be bigger than the small bet.
+func NewStudTable(numSeats, ante, bringIn, smallBet, bigBet int, rndSource rand.Source) (*StudTable, error) {
+	if numSeats < 2 || numSeats > maxStudPlayers {
+		return nil, fmt.Errorf("%w: stud needs 2 to %d seats", ErrInvalidPlayerCount, maxStudPlayers)
+	}
+	if ante < 0 || bringIn <= 0 || smallBet < bringIn || bigBet < smallBet {
+		return nil, fmt.Errorf("%w: need a bring-in no bigger than the small bet, and a big bet no smaller", ErrInvalidAmount)
+	}
+
+	var t = &StudTable{
+		bettingTable: bettingTable{
+			seats:     make([]*Seat, numSeats),
+			structure: FixedLimit{SmallBet: smallBet, BigBet: bigBet, MaxRaises: 4},
+			minBet:    smallBet,
+			toAct:     -1,
+		},
+		ante:      ante,
+		bringIn:   bringIn,
+		rndSource: rndSource,
+	}
+	t.game = t
+	return t, nil
+}
+
+// Street returns the last street dealt, or zero before the first hand
+func (t *StudTable) Street() StudStreet {
+	if t.stud == nil {
+		return 0
+	}
+	return t.stud.Street()
+}
+
+// Community returns a copy of the shared card, if the deck ran short on
+// seventh street
+func (t *StudTable) Community() CardList {
+	if t.stud == nil {
+		return nil
+	}
+	return t.stud.Community()
+}
+
+// Player returns a copy of the down and up cards for the player in the given
+// seat, or false if they weren't dealt into the current (or most recent) hand.
+// The seat's Hand holds the same cards, but doesn't say which are showing.
+func (t *StudTable) Player(seat int) (StudPlayer, bool) {
+	var p = t.player(seat)
+	if p < 0 {
+		return StudPlayer{}, false
+	}
+	return t.stud.Player(p), true
+}
+
+// player returns the Stud player number for the given seat, or -1
+func (t *StudTable) player(seat int) int {
+	for p, n := range t.players {
+		if n == seat {
+			return p
+		}
+	}
+	return -1
+}
+
+// StartHand deals third street to everybody with chips, takes their antes,
+// and posts the bring-in for the lowest up card
+func (t *StudTable) StartHand() error {
+	if t.inProgress {
+		return ErrHandInProgress
+	}
+
+	var players []int
+	for i, s := range t.seats {
+		if s != nil && s.Stack > 0 {
+			players = append(players, i)
+		}
+	}
+	if len(players) < 2 {
+		return fmt.Errorf("%w: need at least two players with chips", ErrInvalidPlayerCount)
+	}
+
+	t.players = players
+	for _, s := range t.seats {
+		if s != nil {
+			*s = Seat{Name: s.Name, Stack: s.Stack}
+		}
+	}
+
+	// Nobody's cards can run out on third street, so these can't fail
+	t.stud, _ = NewStud(len(t.players), t.rndSource)
+	t.stud.Deal()
+	t.showCards()
+
+	t.inProgress = true
+	t.results = nil
+	t.won = make([]int, len(t.seats))
+	t.bigBets = false
+	t.newStreet()
+	if t.ante > 0 {
+		for _, n := range t.players {
+			t.seats[n].put(t.ante)
+			t.seats[n].Bet = 0
+		}
+	}
+
+	// The bring-in has put in their share, so they don't get another turn
+	// unless somebody completes
+	t.toAct = t.players[t.stud.BringIn()]
+	var bringIn = t.seats[t.toAct]
+	bringIn.put(t.bringIn)
+	bringIn.acted = true
+	t.currentBet = bringIn.Bet
+	t.incomplete = t.currentBet > 0 && t.currentBet < t.minBet
+	if t.currentBet >= t.minBet {
+		t.raises = 1
+	}
+	t.advance()
+
+	return nil
+}
+
+// showCards copies each player's cards into their seat's Hand
+func (t *StudTable) showCards() {
+	for p, n := range t.players {
+		t.seats[n].Hand = NewHand(t.stud.Player(p).Cards())
+	}
+}
+
+// Act performs the action for the player whose turn it is, then moves on to
+// the next player, street, or the end of the hand as needed
+func (t *StudTable) Act(a Action) error {
+	var seat = t.toAct
+	var err = t.act(a)
+	if err != nil {
+		return err
+	}
+	if a.Type == Fold {
+		t.stud.Fold(t.player(seat))
+	}
+	t.advance()
+	return nil
+}
+
+// endStreet deals the next street, or goes to showdown after seventh street.
+// The best showing hand acts first, and streets where nobody can bet are
+// dealt out right away.
+func (t *StudTable) endStreet() {
+	t.newStreet()
+	if t.stud.Street() == SeventhStreet {
+		t.showdown()
+		return
+	}
+
+	// Only players still in are dealt, and there's always a card for each
+	t.stud.Deal()
+	t.showCards()
+	t.bigBets = t.stud.Street() >= FifthStreet
+
+	var first = t.players[t.stud.FirstToAct()]
+	t.toAct = t.nextSeat(first+len(t.seats)-1, t.needsToAct)
+	if t.toAct < 0 {
+		t.endStreet()
+	}
+}
+
+// showdown evaluates every hand still in, then settles the pots
+func (t *StudTable) showdown() {
+	// Seventh street has been dealt, so there's always a result
+	var results, _ = t.stud.Results()
+	t.results = make([]*HandResult, len(t.seats))
+	for p, n := range t.players {
+		t.results[n] = results[p]
+	}
+	t.finish()
+}
+
+// finish settles the pots and ends the hand. There's no button in stud, so
+// odd chips go by suit.
+func (t *StudTable) finish() {
+	t.settle(OddChipBySuit)
+}
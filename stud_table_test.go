@@ -0,0 +1,171 @@
+package poker
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// newStudTestTable returns a 1/2 ante and bring-in, 5/10 stud table with a
+// hand started
+func newStudTestTable(t *testing.T, stacks ...int) *StudTable {
+	t.Helper()
+	var table, err = NewStudTable(len(stacks), 1, 2, 5, 10, rand.NewSource(1))
+	if err != nil {
+		t.Fatalf("Unable to create stud table: %s", err)
+	}
+	for i, stack := range stacks {
+		table.Sit(i, string(rune('A'+i)), stack)
+	}
+	err = table.StartHand()
+	if err != nil {
+		t.Fatalf("Unable to start hand: %s", err)
+	}
+	return table
+}
+
+// studBringIn returns the seat which posted the bring-in
+func studBringIn(t *testing.T, table *StudTable) int {
+	t.Helper()
+	for i := 0; i < table.NumSeats(); i++ {
+		var s, _ = table.Seat(i)
+		if s.Bet > 0 {
+			return i
+		}
+	}
+	t.Fatalf("Nobody brought it in")
+	return -1
+}
+
+func TestStudTable(t *testing.T) {
+	var table = newStudTestTable(t, 100, 100, 100)
+	var bringIn = studBringIn(t, table)
+	if table.Street() != ThirdStreet || table.Pot() != 5 || table.CurrentBet() != 2 {
+		t.Fatalf("Expected antes and a bring-in of 2 on third street, got %d in the pot on %s", table.Pot(), table.Street())
+	}
+
+	// The bring-in can be called or completed to the small bet
+	var legal = table.Legal()
+	if legal.Call != 2 || legal.MinRaise != 5 || legal.MaxRaise != 5 {
+		t.Fatalf("Expected to call 2 or complete to 5, got %+v", legal)
+	}
+	var err = table.Act(betTo(5))
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected betting into the bring-in to be %q, got %v", ErrIllegalAction, err)
+	}
+	mustAct(t, table, raiseTo(5))
+	legal = table.Legal()
+	if legal.Call != 5 || legal.MinRaise != 10 || legal.MaxRaise != 10 {
+		t.Fatalf("Expected to call 5 or raise to 10, got %+v", legal)
+	}
+	mustAct(t, table, call)
+	if table.ToAct() != bringIn || table.Legal().Call != 3 {
+		t.Fatalf("Expected the bring-in to call 3 more, got seat %d with %+v", table.ToAct(), table.Legal())
+	}
+	mustAct(t, table, call)
+
+	// Small bets on fourth street, with the best showing hand first
+	if table.Street() != FourthStreet || table.Legal().MinRaise != 5 {
+		t.Fatalf("Expected a 5-chip bet on fourth street, got %+v on %s", table.Legal(), table.Street())
+	}
+	var best = -1
+	var bestShowing []int
+	for i := 0; i < table.NumSeats(); i++ {
+		var p, _ = table.Player(i)
+		var showing = showingValue(p.Up)
+		if best < 0 || compareShowing(showing, bestShowing) > 0 {
+			best, bestShowing = i, showing
+		}
+	}
+	if table.ToAct() != best {
+		t.Errorf("Expected seat %d to act first on fourth street, got %d", best, table.ToAct())
+	}
+	mustAct(t, table, check, check, check)
+
+	// Big bets from fifth street on
+	if table.Street() != FifthStreet || table.Legal().MinRaise != 10 {
+		t.Fatalf("Expected a 10-chip bet on fifth street, got %+v on %s", table.Legal(), table.Street())
+	}
+	for table.InProgress() {
+		mustAct(t, table, check)
+	}
+
+	var total, won int
+	for i, hr := range table.Results() {
+		var s, _ = table.Seat(i)
+		if hr == nil || len(hr.Hand) != 7 || len(s.Hand.cards) != 7 {
+			t.Errorf("Expected seat %d to show seven cards, got %v", i, hr)
+		}
+		total += s.Stack
+		won += table.Won()[i]
+	}
+	if total != 300 || won != 18 {
+		t.Errorf("Expected 18 chips won and 300 in stacks, got %d and %d", won, total)
+	}
+}
+
+func TestStudTableFoldToBringIn(t *testing.T) {
+	var table = newStudTestTable(t, 100, 100, 100)
+	var bringIn = studBringIn(t, table)
+	mustAct(t, table, fold, fold)
+
+	if table.InProgress() || table.Results() != nil {
+		t.Fatalf("Expected the hand to end without a showdown")
+	}
+	// The uncalled bring-in goes back, and the antes are won
+	var s, _ = table.Seat(bringIn)
+	if table.Won()[bringIn] != 3 || s.Stack != 102 {
+		t.Errorf("Expected the bring-in to win the antes, got %v and a stack of %d", table.Won(), s.Stack)
+	}
+	var p, _ = table.Player(bringIn)
+	if len(p.Cards()) != 3 {
+		t.Errorf("Expected the hand to end on third street, got %s", p.Cards())
+	}
+}
+
+func TestStudTableFullBringIn(t *testing.T) {
+	var table, _ = NewStudTable(2, 0, 5, 5, 10, rand.NewSource(1))
+	table.Sit(0, "A", 100)
+	table.Sit(1, "B", 100)
+	table.StartHand()
+
+	// A bring-in of a full small bet is raised like any other bet
+	var legal = table.Legal()
+	if legal.Call != 5 || legal.MinRaise != 10 {
+		t.Errorf("Expected to call 5 or raise to 10, got %+v", legal)
+	}
+}
+
+func TestStudTableErrors(t *testing.T) {
+	var tests = map[string]struct {
+		seats, ante, bringIn, small, big int
+		err                              error
+	}{
+		"One seat":        {1, 1, 2, 5, 10, ErrInvalidPlayerCount},
+		"Nine seats":      {9, 1, 2, 5, 10, ErrInvalidPlayerCount},
+		"Negative ante":   {4, -1, 2, 5, 10, ErrInvalidAmount},
+		"No bring-in":     {4, 1, 0, 5, 10, ErrInvalidAmount},
+		"Big bring-in":    {4, 1, 6, 5, 10, ErrInvalidAmount},
+		"Small big bet":   {4, 1, 2, 5, 4, ErrInvalidAmount},
+		"Everything fine": {4, 0, 2, 5, 10, nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var _, err = NewStudTable(tc.seats, tc.ante, tc.bringIn, tc.small, tc.big, rand.NewSource(1))
+			if !errors.Is(err, tc.err) {
+				t.Errorf("Expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+
+	var table, _ = NewStudTable(3, 1, 2, 5, 10, rand.NewSource(1))
+	table.Sit(0, "A", 100)
+	var err = table.StartHand()
+	if !errors.Is(err, ErrInvalidPlayerCount) {
+		t.Errorf("Expected one player to be %q, got %v", ErrInvalidPlayerCount, err)
+	}
+	if table.Street() != 0 || !errors.Is(table.Act(check), ErrNoHandInProgress) {
+		t.Errorf("Expected no hand to be in progress")
+	}
+}
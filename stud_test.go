@@ -0,0 +1,231 @@
+package poker
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func mustDealStud(t *testing.T, s *Stud, streets int) {
+	t.Helper()
+	for i := 0; i < streets; i++ {
+		var err = s.Deal()
+		if err != nil {
+			t.Fatalf("Unable to deal %s: %s", s.Street()+1, err)
+		}
+	}
+}
+
+func TestStudDeal(t *testing.T) {
+	var s, err = NewStud(3, rand.NewSource(1))
+	if err != nil {
+		t.Fatalf("Unable to create stud game: %s", err)
+	}
+
+	var expected = map[StudStreet][2]int{
+		ThirdStreet:   {2, 1},
+		FourthStreet:  {2, 2},
+		FifthStreet:   {2, 3},
+		SixthStreet:   {2, 4},
+		SeventhStreet: {3, 4},
+	}
+	for street := ThirdStreet; street <= SeventhStreet; street++ {
+		mustDealStud(t, s, 1)
+		if s.Street() != street {
+			t.Fatalf("Expected %s, got %s", street, s.Street())
+		}
+		for i := 0; i < s.NumPlayers(); i++ {
+			var p = s.Player(i)
+			if len(p.Down) != expected[street][0] || len(p.Up) != expected[street][1] {
+				t.Fatalf("%s: expected player %d to have %v down/up, got %s / %s", street, i, expected[street], p.Down, p.Up)
+			}
+		}
+	}
+
+	var seen CardSet
+	for i := 0; i < s.NumPlayers(); i++ {
+		for _, c := range s.Player(i).Cards() {
+			if seen.Contains(c) {
+				t.Fatalf("%s was dealt twice", c)
+			}
+			seen.Add(c)
+		}
+	}
+	if len(s.Community()) != 0 {
+		t.Errorf("Expected no community card, got %s", s.Community())
+	}
+
+	err = s.Deal()
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected dealing past seventh street to be %q, got %v", ErrIllegalAction, err)
+	}
+
+	var results []*HandResult
+	results, err = s.Results()
+	if err != nil {
+		t.Fatalf("Unable to get results: %s", err)
+	}
+	for i, hr := range results {
+		if hr == nil || len(hr.Hand) != 7 {
+			t.Errorf("Expected a seven-card result for player %d, got %v", i, hr)
+		}
+	}
+}
+
+func TestStudFold(t *testing.T) {
+	var s, _ = NewStud(3, rand.NewSource(1))
+	var err = s.Fold(1)
+	if !errors.Is(err, ErrNoHandInProgress) {
+		t.Errorf("Expected folding before the deal to be %q, got %v", ErrNoHandInProgress, err)
+	}
+
+	mustDealStud(t, s, 1)
+	var _, resultErr = s.Results()
+	if !errors.Is(resultErr, ErrIllegalAction) {
+		t.Errorf("Expected results on third street to be %q, got %v", ErrIllegalAction, resultErr)
+	}
+
+	err = s.Fold(1)
+	if err != nil {
+		t.Fatalf("Unable to fold: %s", err)
+	}
+	mustDealStud(t, s, 4)
+	if len(s.Player(1).Cards()) != 3 || len(s.Player(0).Cards()) != 7 {
+		t.Errorf("Expected the folded player to stop getting cards, got %s and %s", s.Player(1).Cards(), s.Player(0).Cards())
+	}
+
+	var results, _ = s.Results()
+	if results[1] != nil || results[0] == nil || results[2] == nil {
+		t.Errorf("Expected results for players 0 and 2 only, got %v", results)
+	}
+
+	s.Reset()
+	mustDealStud(t, s, 2)
+	if s.Fold(0) != nil || s.Fold(2) != nil {
+		t.Fatalf("Unable to fold")
+	}
+	err = s.Fold(1)
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected the last player folding to be %q, got %v", ErrIllegalAction, err)
+	}
+	err = s.Deal()
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected dealing to one player to be %q, got %v", ErrIllegalAction, err)
+	}
+	results, err = s.Results()
+	if err != nil || len(results) != 3 || results[1] != nil {
+		t.Errorf("Expected nobody to show down, got %v (%v)", results, err)
+	}
+}
+
+func TestStudCommunityCard(t *testing.T) {
+	var s, _ = NewStud(8, rand.NewSource(1))
+	mustDealStud(t, s, 5)
+
+	if len(s.Community()) != 1 {
+		t.Fatalf("Expected a community card, got %s", s.Community())
+	}
+	var community = s.Community()
+	community[0] = 0
+	if s.Community()[0] == 0 {
+		t.Errorf("Expected Community to return a copy")
+	}
+	var results, err = s.Results()
+	if err != nil {
+		t.Fatalf("Unable to get results: %s", err)
+	}
+	for i, hr := range results {
+		if len(s.Player(i).Cards()) != 6 || hr == nil || len(hr.Hand) != 7 {
+			t.Errorf("Expected player %d to have six cards plus the community card, got %v", i, hr)
+		}
+	}
+}
+
+func TestNewStudPlayers(t *testing.T) {
+	for _, n := range []int{1, 9} {
+		var _, err = NewStud(n, rand.NewSource(1))
+		if !errors.Is(err, ErrInvalidPlayerCount) {
+			t.Errorf("Expected %d players to be %q, got %v", n, ErrInvalidPlayerCount, err)
+		}
+	}
+}
+
+// studShowing returns a game on the given street where each player shows
+// the given up cards. Players whose cards start with "x" have folded.
+func studShowing(t *testing.T, street StudStreet, ups ...string) *Stud {
+	t.Helper()
+	var s, _ = NewStud(len(ups), rand.NewSource(1))
+	s.street = street
+	for i, up := range ups {
+		var folded = strings.HasPrefix(up, "x")
+		var cards, err = ParseCards(strings.TrimPrefix(up, "x"))
+		if err != nil {
+			t.Fatalf("Unable to parse %q: %s", up, err)
+		}
+		s.players[i] = &StudPlayer{Up: cards, Folded: folded}
+	}
+	return s
+}
+
+func TestStudBringIn(t *testing.T) {
+	var tests = map[string]struct {
+		ups      []string
+		expected int
+	}{
+		"Lowest rank":       {[]string{"9s", "4h", "Kd"}, 1},
+		"Clubs lowest":      {[]string{"2d", "2c", "2h"}, 1},
+		"Diamonds":          {[]string{"2s", "2h", "2d"}, 2},
+		"Hearts":            {[]string{"2s", "2h", "5c"}, 1},
+		"Aces are high":     {[]string{"Ac", "Kd"}, 1},
+		"Folded is skipped": {[]string{"3s", "x2c", "4d"}, 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var s = studShowing(t, ThirdStreet, tc.ups...)
+			var got = s.BringIn()
+			if got != tc.expected {
+				t.Errorf("Expected player %d to bring it in, got %d", tc.expected, got)
+			}
+			if s.FirstToAct() != got {
+				t.Errorf("Expected the bring-in to act first on third street, got %d", s.FirstToAct())
+			}
+		})
+	}
+}
+
+func TestStudFirstToAct(t *testing.T) {
+	var tests = map[string]struct {
+		street   StudStreet
+		ups      []string
+		expected int
+	}{
+		"High card":             {FourthStreet, []string{"Ks Qh", "Ad 2c", "Jh Tc"}, 1},
+		"Next card":             {FourthStreet, []string{"Ad 9c", "Ah Tc", "3h 4c"}, 1},
+		"Pair beats high cards": {FourthStreet, []string{"As Kh", "7d 7c"}, 1},
+		"Higher pair":           {FifthStreet, []string{"8d 8c 2s", "9s 9h 3d"}, 1},
+		"Pair kicker":           {FifthStreet, []string{"8d 8c 2s", "8s 8h 3d"}, 1},
+		"Two pair beats pair":   {SixthStreet, []string{"As Ah Kd Qc", "3s 3h 2d 2c"}, 1},
+		"Trips beat two pair":   {SixthStreet, []string{"Ks Kh Qd Qc", "4s 4h 4d 2c"}, 1},
+		"Quads":                 {SeventhStreet, []string{"5s 5h 5d 5c", "As Ah Ad Kc"}, 0},
+		"No flushes":            {SixthStreet, []string{"2h 5h 7h 9h", "Ac 3d 4s 6s"}, 1},
+		"No straights":          {SixthStreet, []string{"9c Td Jh Qs", "Kc 2d 3h 5s"}, 1},
+		"Tie goes left":         {FourthStreet, []string{"4c 6d", "Kd 9c", "Ks 9h"}, 1},
+		"Folded is skipped":     {FifthStreet, []string{"xAs Ah Ad", "7s 8s 9s", "2c 2d 3h"}, 2},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got = studShowing(t, tc.street, tc.ups...).FirstToAct()
+			if got != tc.expected {
+				t.Errorf("Expected player %d to act first, got %d", tc.expected, got)
+			}
+		})
+	}
+
+	var s, _ = NewStud(2, rand.NewSource(1))
+	if s.FirstToAct() != -1 || s.BringIn() != -1 {
+		t.Errorf("Expected nobody to act before the deal")
+	}
+}
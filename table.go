@@ -0,0 +1,355 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Street is one round of dealing and betting in a Hold 'em hand
+type Street int
+
+// All streets, in order. Showdown is where a hand ends up when more than one
+// player is left after the river.
+const (
+	Preflop Street = iota + 1
+	Flop
+	Turn
+	River
+	Showdown
+)
+
+func (s Street) String() string {
+	switch s {
+	case Preflop:
+		return "Preflop"
+	case Flop:
+		return "Flop"
+	case Turn:
+		return "Turn"
+	case River:
+		return "River"
+	case Showdown:
+		return "Showdown"
+	}
+
+	return ""
+}
+
+// ActionType is the kind of thing a player does on their turn
+type ActionType int
+
+// All action types
+const (
+	Fold ActionType = iota + 1
+	Check
+	Call
+	Bet
+	Raise
+)
+
+func (at ActionType) String() string {
+	switch at {
+	case Fold:
+		return "Fold"
+	case Check:
+		return "Check"
+	case Call:
+		return "Call"
+	case Bet:
+		return "Bet"
+	case Raise:
+		return "Raise"
+	}
+
+	return ""
+}
+
+// Action is what a player does when it's their turn. For a bet or raise,
+// Amount is the player's total bet for the street afterward ("raise to"), not
+// the chips being added. Amount is ignored for any other action.
+type Action struct {
+	Type   ActionType
+	Amount int
+}
+
+// String returns a human-friendly action, e.g., "Call" or "Raise to 300"
+func (a Action) String() string {
+	switch a.Type {
+	case Bet:
+		return fmt.Sprintf("Bet %d", a.Amount)
+	case Raise:
+		return fmt.Sprintf("Raise to %d", a.Amount)
+	}
+	return a.Type.String()
+}
+
+// LegalActions describes what the player to act may do. Folding is always
+// allowed.
+type LegalActions struct {
+	// Check is true if the player doesn't have to put in any chips to continue
+	Check bool
+
+	// Call is the number of chips needed to call, which is less than the bet
+	// when the player doesn't have enough to cover it, or zero if there's
+	// nothing to call
+	Call int
+
+	// MinRaise and MaxRaise are the smallest and largest totals the player may
+	// bet or raise to, or zero if the player isn't allowed to bet or raise. An
+	// all-in for less than a full raise is allowed, in which case MinRaise is
+	// the same as MaxRaise.
+	MinRaise int
+	MaxRaise int
+}
+
+// Seat is a player sitting at a Table
+type Seat struct {
+	Name string
+
+	// Stack is the player's chips which haven't been put into the pot
+	Stack int
+
+	// Hand holds the player's hole cards, or nil if the player wasn't dealt
+	// into the current hand
+	Hand *Hand
+
+	// Bet is what the player has put in on the current street, and Total is
+	// what they've put in during the whole hand, including Bet
+	Bet   int
+	Total int
+
+	Folded bool
+	AllIn  bool
+
+	// acted is true once the player has acted since the last full bet or
+	// raise, which means a short all-in raise can't reopen their betting
+	acted bool
+}
+
+// inHand returns true if the player was dealt in and hasn't folded
+func (s *Seat) inHand() bool {
+	return s != nil && s.Hand != nil && !s.Folded
+}
+
+// canAct returns true if the player is in the hand and has chips to bet
+func (s *Seat) canAct() bool {
+	return s.inHand() && !s.AllIn
+}
+
+// put moves chips from the player's stack into their bet, going all in if
+// they don't have enough
+func (s *Seat) put(chips int) {
+	if chips >= s.Stack {
+		chips = s.Stack
+		s.AllIn = true
+	}
+	s.Stack -= chips
+	s.Bet += chips
+	s.Total += chips
+}
+
+// maxSeats keeps every seat's hole cards, the board, and a burn card before
+// each street within a single 52-card deck
+const maxSeats = 22
+
+// Table runs Texas Hold 'em and Omaha hands: it moves the button, posts
+// blinds, deals, enforces whose turn it is and what they may do, and settles
+// the main and side pots at the end. Everything random comes from the
+// rand.Source given to NewTable, so the same source and the same actions will
+// replay a hand exactly.
+//
+// Tables start out as No-Limit Hold 'em. Use SetBettingStructure and
+// SetHoleCards between hands for other games, e.g., PotLimit with four hole
+// cards for Pot-Limit Omaha.
+//
+// A Table is *not* safe for concurrent use.
+type Table struct {
+	bettingTable
+
+	smallBlind int
+	bigBlind   int
+	deck       *Deck
+	button     int
+	holeCards  int
+
+	street    Street
+	community CardList
+}
+
+// NewTable returns an empty table with the given number of seats (two to
+// twenty-two) and blinds
+func NewTable(numSeats, smallBlind, bigBlind int, rndSource rand.Source) (*Table, error) {
+	if numSeats < 2 || numSeats > maxSeats {
+		return nil, fmt.Errorf("%w: a table needs 2 to %d seats", ErrInvalidPlayerCount, maxSeats)
+	}
+	if smallBlind <= 0 || bigBlind < smallBlind {
+		return nil, fmt.Errorf("%w: blinds must be positive, and the big blind can't be smaller", ErrInvalidAmount)
+	}
+
+	var t = &Table{
+		bettingTable: bettingTable{
+			seats:     make([]*Seat, numSeats),
+			structure: NoLimit{},
+			minBet:    bigBlind,
+			toAct:     -1,
+		},
+		smallBlind: smallBlind,
+		bigBlind:   bigBlind,
+		deck:       NewDeck(rndSource),
+		button:     -1,
+		holeCards:  2,
+	}
+	t.game = t
+	return t, nil
+}
+
+// SetHoleCards changes how many hole cards each player is dealt, starting
+// with the next hand: two for Hold 'em, or four to six for Omaha. Every seat
+// has to fit in one deck, so big Omaha games need smaller tables.
+func (t *Table) SetHoleCards(n int) error {
+	if t.inProgress {
+		return ErrHandInProgress
+	}
+	if n != 2 && (n < 4 || n > 6) {
+		return fmt.Errorf("%w: hole cards must be two, four, five, or six", ErrInvalidCardCount)
+	}
+	if n*len(t.seats)+8 > 52 {
+		return fmt.Errorf("%w: %d seats can't each get %d cards from one deck", ErrInvalidCardCount, len(t.seats), n)
+	}
+	t.holeCards = n
+	return nil
+}
+
+// Button returns the dealer button's seat, or -1 before the first hand
+func (t *Table) Button() int {
+	return t.button
+}
+
+// Street returns the current street, or where the last hand ended up
+func (t *Table) Street() Street {
+	return t.street
+}
+
+// Community returns a copy of the community cards dealt so far
+func (t *Table) Community() CardList {
+	return append(CardList(nil), t.community...)
+}
+
+// StartHand moves the button, posts blinds, and deals everybody with chips
+// their hole cards. Heads up, the button posts the small blind and acts first
+// before the flop.
+func (t *Table) StartHand() error {
+	if t.inProgress {
+		return ErrHandInProgress
+	}
+
+	var players int
+	for _, s := range t.seats {
+		if s != nil && s.Stack > 0 {
+			players++
+		}
+	}
+	if players < 2 {
+		return fmt.Errorf("%w: need at least two players with chips", ErrInvalidPlayerCount)
+	}
+
+	var dealtIn = func(s *Seat) bool { return s.Hand != nil }
+	for _, s := range t.seats {
+		if s == nil {
+			continue
+		}
+		*s = Seat{Name: s.Name, Stack: s.Stack}
+		if s.Stack > 0 {
+			s.Hand = NewHand(nil)
+		}
+	}
+
+	t.inProgress = true
+	t.street = Preflop
+	t.community = nil
+	t.results = nil
+	t.won = make([]int, len(t.seats))
+	t.button = t.nextSeat(t.button, dealtIn)
+
+	var sb = t.nextSeat(t.button, dealtIn)
+	if players == 2 {
+		sb = t.button
+	}
+	var bb = t.nextSeat(sb, dealtIn)
+
+	t.deck.Reset()
+	t.deck.Shuffle()
+	for round := 0; round < t.holeCards; round++ {
+		for i, n := 0, t.button; i < players; i++ {
+			n = t.nextSeat(n, dealtIn)
+			t.deck.Deal(t.seats[n].Hand)
+		}
+	}
+
+	t.seats[sb].put(t.smallBlind)
+	t.seats[bb].put(t.bigBlind)
+	t.currentBet = t.bigBlind
+	t.lastRaise = t.bigBlind
+	t.raises = 1
+	t.bigBets = false
+	t.incomplete = false
+	t.toAct = bb
+	t.advance()
+
+	return nil
+}
+
+// Act performs the action for the player whose turn it is, then moves on to
+// the next player, street, or the end of the hand as needed
+func (t *Table) Act(a Action) error {
+	var err = t.act(a)
+	if err != nil {
+		return err
+	}
+	t.advance()
+	return nil
+}
+
+// endStreet returns any uncalled bet, then deals the next street, or goes to
+// showdown after the river. Streets where nobody can bet are dealt out right
+// away.
+func (t *Table) endStreet() {
+	t.newStreet()
+	if t.street == River {
+		t.showdown()
+		return
+	}
+
+	t.street++
+	t.bigBets = t.street >= Turn
+	t.deck.Draw(1)
+	if t.street == Flop {
+		t.community = append(t.community, t.deck.Draw(3)...)
+	} else {
+		t.community = append(t.community, t.deck.Draw(1)...)
+	}
+
+	t.toAct = t.nextSeat(t.button, t.needsToAct)
+	if t.toAct < 0 {
+		t.endStreet()
+	}
+}
+
+// showdown evaluates every hand still in, then settles the pots
+func (t *Table) showdown() {
+	t.street = Showdown
+	t.results = make([]*HandResult, len(t.seats))
+	for i, s := range t.seats {
+		if s.inHand() {
+			// Two hole cards and a full board can't fail to evaluate
+			t.results[i], _ = s.Hand.Evaluate(t.community...)
+		}
+	}
+	t.finish()
+}
+
+// finish settles the pots and ends the hand. Odd chips go to the winners
+// closest to the left of the button.
+func (t *Table) finish() {
+	t.settle(OddChipLeftOfButton(t.button, len(t.seats)))
+}
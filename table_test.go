@@ -0,0 +1,388 @@
+package poker
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// newTestTable seats one player per stack, starting at seat 0, at a table
+// with 5/10 blinds, and starts the first hand
+func newTestTable(t *testing.T, seed int64, stacks ...int) *Table {
+	t.Helper()
+	var table, err = NewTable(len(stacks), 5, 10, rand.NewSource(seed))
+	if err != nil {
+		t.Fatalf("Unable to create table: %s", err)
+	}
+	for i, stack := range stacks {
+		err = table.Sit(i, string(rune('A'+i)), stack)
+		if err != nil {
+			t.Fatalf("Unable to seat player %d: %s", i, err)
+		}
+	}
+	err = table.StartHand()
+	if err != nil {
+		t.Fatalf("Unable to start hand: %s", err)
+	}
+	return table
+}
+
+// actor is a Table or StudTable
+type actor interface {
+	ToAct() int
+	Act(a Action) error
+}
+
+// mustAct runs each action in order, failing the test if any is rejected
+func mustAct(t *testing.T, table actor, actions ...Action) {
+	t.Helper()
+	for _, a := range actions {
+		var seat = table.ToAct()
+		var err = table.Act(a)
+		if err != nil {
+			t.Fatalf("Seat %d couldn't %s: %s", seat, a, err)
+		}
+	}
+}
+
+// seat returns the player in the given seat, failing if it's empty
+func seat(t *testing.T, table *Table, i int) Seat {
+	t.Helper()
+	var s, ok = table.Seat(i)
+	if !ok {
+		t.Fatalf("Seat %d is empty", i)
+	}
+	return s
+}
+
+// chipTotal adds up every player's stack
+func chipTotal(table *Table) int {
+	var total int
+	for i := 0; i < table.NumSeats(); i++ {
+		var s, _ = table.Seat(i)
+		total += s.Stack
+	}
+	return total
+}
+
+var (
+	fold  = Action{Type: Fold}
+	check = Action{Type: Check}
+	call  = Action{Type: Call}
+)
+
+func raiseTo(n int) Action { return Action{Type: Raise, Amount: n} }
+func betTo(n int) Action   { return Action{Type: Bet, Amount: n} }
+
+func TestTableBlinds(t *testing.T) {
+	var table = newTestTable(t, 1, 1000, 1000, 1000)
+
+	if table.Button() != 0 {
+		t.Errorf("Expected the button in seat 0, got %d", table.Button())
+	}
+	if seat(t, table, 1).Bet != 5 || seat(t, table, 2).Bet != 10 {
+		t.Errorf("Expected blinds of 5 and 10, got %d and %d", seat(t, table, 1).Bet, seat(t, table, 2).Bet)
+	}
+	if table.ToAct() != 0 {
+		t.Errorf("Expected seat 0 to act first, got %d", table.ToAct())
+	}
+	if table.Pot() != 15 || table.Street() != Preflop {
+		t.Errorf("Expected a preflop pot of 15, got %d on the %s", table.Pot(), table.Street())
+	}
+	for i := 0; i < 3; i++ {
+		if seat(t, table, i).Hand == nil || len(seat(t, table, i).Hand.cards) != 2 {
+			t.Errorf("Expected seat %d to have two hole cards", i)
+		}
+	}
+}
+
+func TestTableHeadsUp(t *testing.T) {
+	var table = newTestTable(t, 1, 1000, 1000)
+
+	// The button posts the small blind and acts first preflop...
+	if seat(t, table, 0).Bet != 5 || table.ToAct() != 0 {
+		t.Fatalf("Expected the button to post 5 and act first, got %d and seat %d", seat(t, table, 0).Bet, table.ToAct())
+	}
+	mustAct(t, table, call, check)
+
+	// ...and last after the flop
+	if table.Street() != Flop || len(table.Community()) != 3 {
+		t.Fatalf("Expected a flop, got %s with %s", table.Street(), table.Community())
+	}
+	if table.ToAct() != 1 {
+		t.Errorf("Expected the big blind to act first on the flop, got %d", table.ToAct())
+	}
+}
+
+func TestTableFoldToBigBlind(t *testing.T) {
+	var table = newTestTable(t, 1, 1000, 1000, 1000)
+	mustAct(t, table, fold, fold)
+
+	if table.InProgress() {
+		t.Fatalf("Expected the hand to be over")
+	}
+	if seat(t, table, 2).Stack != 1005 || seat(t, table, 1).Stack != 995 {
+		t.Errorf("Expected the big blind to win the small blind, got stacks %d and %d", seat(t, table, 2).Stack, seat(t, table, 1).Stack)
+	}
+	if table.Results() != nil {
+		t.Errorf("Expected no showdown results")
+	}
+
+	// The next hand moves the button along
+	var err = table.StartHand()
+	if err != nil {
+		t.Fatalf("Unable to start second hand: %s", err)
+	}
+	if table.Button() != 1 || table.ToAct() != 1 {
+		t.Errorf("Expected button in seat 1 and acting first, got %d and %d", table.Button(), table.ToAct())
+	}
+}
+
+func TestTableBigBlindOption(t *testing.T) {
+	var table = newTestTable(t, 1, 1000, 1000, 1000)
+	mustAct(t, table, call, call)
+
+	if table.Street() != Preflop || table.ToAct() != 2 {
+		t.Fatalf("Expected the big blind to get an option, got seat %d on the %s", table.ToAct(), table.Street())
+	}
+	var legal = table.Legal()
+	if !legal.Check || legal.Call != 0 || legal.MinRaise != 20 || legal.MaxRaise != 1000 {
+		t.Errorf("Unexpected legal actions for the big blind: %+v", legal)
+	}
+	mustAct(t, table, check)
+	if table.Street() != Flop || table.ToAct() != 1 {
+		t.Errorf("Expected the small blind to act first on the flop, got seat %d on the %s", table.ToAct(), table.Street())
+	}
+}
+
+func TestTableIllegalActions(t *testing.T) {
+	var table = newTestTable(t, 1, 1000, 1000, 1000)
+
+	var tests = map[string]Action{
+		"Check facing a bet":   check,
+		"Bet instead of raise": betTo(30),
+		"Raise too small":      raiseTo(15),
+		"Raise too big":        raiseTo(1001),
+		"Unknown action":       {Type: ActionType(99)},
+	}
+	for name, a := range tests {
+		t.Run(name, func(t *testing.T) {
+			var err = table.Act(a)
+			if !errors.Is(err, ErrIllegalAction) {
+				t.Errorf("Expected %q, got %v", ErrIllegalAction, err)
+			}
+		})
+	}
+	if table.ToAct() != 0 || table.Pot() != 15 {
+		t.Fatalf("Illegal actions must not change anything")
+	}
+
+	// Min-raise is the size of the last raise, so 30 means at least 50 next
+	mustAct(t, table, raiseTo(30))
+	var legal = table.Legal()
+	if legal.Call != 25 || legal.MinRaise != 50 {
+		t.Errorf("Expected to call 25 or raise to 50, got %+v", legal)
+	}
+	var err = table.Act(raiseTo(49))
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected a raise to 49 to be illegal, got %v", err)
+	}
+	mustAct(t, table, raiseTo(50), call, call)
+
+	// Postflop, there's no bet to call or raise
+	if table.Street() != Flop {
+		t.Fatalf("Expected the flop, got %s", table.Street())
+	}
+	err = table.Act(raiseTo(20))
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected a raise with no bet to be illegal, got %v", err)
+	}
+	err = table.Act(call)
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected a call with no bet to be illegal, got %v", err)
+	}
+	err = table.Act(betTo(5))
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected a bet below the big blind to be illegal, got %v", err)
+	}
+	mustAct(t, table, betTo(10))
+}
+
+func TestTableShowdown(t *testing.T) {
+	var table = newTestTable(t, 7, 1000, 1000, 1000)
+	var chips = chipTotal(table) + table.Pot()
+	mustAct(t, table, call, call, check)
+	for table.InProgress() {
+		mustAct(t, table, check)
+	}
+
+	if table.Street() != Showdown || len(table.Community()) != 5 {
+		t.Fatalf("Expected a showdown with five community cards, got %s with %s", table.Street(), table.Community())
+	}
+
+	var results = table.Results()
+	var won int
+	for i := 0; i < 3; i++ {
+		if results[i] == nil {
+			t.Errorf("Expected seat %d to have a showdown result", i)
+		}
+		won += table.Won()[i]
+	}
+	if won != 30 {
+		t.Errorf("Expected 30 chips to be won, got %d", won)
+	}
+	for _, i := range Winners(results...) {
+		if table.Won()[i] == 0 {
+			t.Errorf("Winner %d didn't win any chips", i)
+		}
+	}
+	if chipTotal(table) != chips {
+		t.Errorf("Expected %d chips on the table, got %d", chips, chipTotal(table))
+	}
+}
+
+func TestTableAllInRunout(t *testing.T) {
+	var table = newTestTable(t, 3, 1000, 300)
+
+	// An all-in that's only partly called gets the rest back
+	mustAct(t, table, raiseTo(1000), call)
+
+	if table.InProgress() || table.Street() != Showdown || len(table.Community()) != 5 {
+		t.Fatalf("Expected the board to run out to showdown, got %s with %s", table.Street(), table.Community())
+	}
+	if table.Pot() != 600 {
+		t.Errorf("Expected a 600-chip pot, got %d", table.Pot())
+	}
+	if chipTotal(table) != 1300 {
+		t.Errorf("Expected 1300 chips on the table, got %d", chipTotal(table))
+	}
+	if seat(t, table, 0).Stack < 700 {
+		t.Errorf("Expected seat 0 to keep at least the uncalled 700, got %d", seat(t, table, 0).Stack)
+	}
+}
+
+func TestTableShortAllInDoesNotReopen(t *testing.T) {
+	var table = newTestTable(t, 1, 45, 1000, 1000, 1000)
+
+	// Button is seat 0, so seat 3 is first to act
+	mustAct(t, table, raiseTo(30))
+
+	// Seat 0 is all in for 45, which is less than a full raise to 50
+	var legal = table.Legal()
+	if legal.MinRaise != 45 || legal.MaxRaise != 45 {
+		t.Fatalf("Expected the short stack's only raise to be all in for 45, got %+v", legal)
+	}
+	mustAct(t, table, raiseTo(45), fold)
+
+	// The big blind hasn't acted, so it can still raise
+	legal = table.Legal()
+	if table.ToAct() != 2 || legal.MinRaise != 65 {
+		t.Fatalf("Expected the big blind to be able to raise to 65, got seat %d with %+v", table.ToAct(), legal)
+	}
+	mustAct(t, table, call)
+
+	// But the original raiser can only call or fold
+	legal = table.Legal()
+	if table.ToAct() != 3 || legal.Call != 15 || legal.MaxRaise != 0 {
+		t.Fatalf("Expected seat 3 to only be able to call 15, got seat %d with %+v", table.ToAct(), legal)
+	}
+	var err = table.Act(raiseTo(100))
+	if !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected a re-raise to be illegal, got %v", err)
+	}
+	mustAct(t, table, call)
+	if table.Street() != Flop {
+		t.Errorf("Expected the flop, got %s", table.Street())
+	}
+}
+
+func TestTableOddChips(t *testing.T) {
+	var table = newTestTable(t, 1, 1000, 1000, 1000)
+	mustAct(t, table, call, fold, check)
+
+	// Pretend seats 0 and 2 tied for the 25-chip pot
+	table.results = []*HandResult{{Score: 100}, nil, {Score: 100}}
+	table.finish()
+	if table.Won()[0] != 12 || table.Won()[2] != 13 {
+		t.Errorf("Expected the odd chip to go left of the button, got %v", table.Won())
+	}
+}
+
+func TestTableReplay(t *testing.T) {
+	var play = func() (CardList, string) {
+		var table = newTestTable(t, 42, 1000, 1000, 1000)
+		var s = seat(t, table, 1)
+		var hole = s.Hand.String()
+		mustAct(t, table, call, call, check)
+		for table.InProgress() {
+			mustAct(t, table, check)
+		}
+		return table.Community(), hole
+	}
+
+	var comm1, hole1 = play()
+	var comm2, hole2 = play()
+	if comm1.String() != comm2.String() || hole1 != hole2 {
+		t.Errorf("Expected the same seed to deal the same hand, got %s / %s and %s / %s", hole1, comm1, hole2, comm2)
+	}
+}
+
+func TestTableErrors(t *testing.T) {
+	var _, err = NewTable(1, 5, 10, rand.NewSource(1))
+	if !errors.Is(err, ErrInvalidPlayerCount) {
+		t.Errorf("Expected a one-seat table to be %q, got %v", ErrInvalidPlayerCount, err)
+	}
+	_, err = NewTable(6, 10, 5, rand.NewSource(1))
+	if !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("Expected backwards blinds to be %q, got %v", ErrInvalidAmount, err)
+	}
+
+	var table, _ = NewTable(3, 5, 10, rand.NewSource(1))
+	err = table.Act(check)
+	if !errors.Is(err, ErrNoHandInProgress) {
+		t.Errorf("Expected acting with no hand to be %q, got %v", ErrNoHandInProgress, err)
+	}
+
+	table.Sit(0, "A", 1000)
+	err = table.StartHand()
+	if !errors.Is(err, ErrInvalidPlayerCount) {
+		t.Errorf("Expected one player to be %q, got %v", ErrInvalidPlayerCount, err)
+	}
+
+	var tests = map[string]struct {
+		seat  int
+		stack int
+		err   error
+	}{
+		"Taken":    {0, 1000, ErrSeatTaken},
+		"Negative": {-1, 1000, ErrInvalidSeat},
+		"Too high": {3, 1000, ErrInvalidSeat},
+		"No chips": {1, 0, ErrInvalidAmount},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var err = table.Sit(tc.seat, "B", tc.stack)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("Expected %q, got %v", tc.err, err)
+			}
+		})
+	}
+
+	table.Sit(1, "B", 1000)
+	table.StartHand()
+	err = table.StartHand()
+	if !errors.Is(err, ErrHandInProgress) {
+		t.Errorf("Expected starting a second hand to be %q, got %v", ErrHandInProgress, err)
+	}
+	err = table.Stand(0)
+	if !errors.Is(err, ErrHandInProgress) {
+		t.Errorf("Expected leaving mid-hand to be %q, got %v", ErrHandInProgress, err)
+	}
+
+	// Somebody who sits down mid-hand can leave, since they weren't dealt in
+	table.Sit(2, "C", 1000)
+	err = table.Stand(2)
+	if err != nil {
+		t.Errorf("Expected a player not in the hand to be able to leave, got %v", err)
+	}
+}